@@ -0,0 +1,97 @@
+package backtest
+
+import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/configs"
+	"backend-loan-pre-approval/pkg/fx"
+	"backend-loan-pre-approval/pkg/scorecard"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const dateLayout = "2006-01-02"
+
+// RunCommand implements `backend-server backtest`. It replays the stored
+// applications through the ruleset file given by -ruleset, with the
+// configured exchange rates and scorecard, and writes the report to -out or
+// stdout.
+//
+//	backend-server backtest -ruleset configs/rulesets/candidate.example.yaml \
+//		-from 2025-01-01 -to 2026-01-01 -format csv -out backtest.csv
+func RunCommand(ctx context.Context, args []string, db *sqlx.DB, appconf configs.AppConfig, stdout io.Writer) error {
+	fs := flag.NewFlagSet("backtest", flag.ContinueOnError)
+	rulesetFile := fs.String("ruleset", "", "candidate ruleset file (required)")
+	fromFlag := fs.String("from", "", "first application date, YYYY-MM-DD (default: all)")
+	toFlag := fs.String("to", "", "end date, exclusive, YYYY-MM-DD (default: now)")
+	format := fs.String("format", FormatJSON, "report format: json or csv")
+	out := fs.String("out", "", "output file (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *rulesetFile == "" {
+		return errors.New("backtest: -ruleset is required")
+	}
+	if *format != FormatJSON && *format != FormatCSV {
+		return errors.New("backtest: -format must be json or csv")
+	}
+
+	params := Params{
+		From: time.Unix(0, 0).UTC(),
+		To:   time.Now(),
+	}
+	var err error
+	if *fromFlag != "" {
+		if params.From, err = time.Parse(dateLayout, *fromFlag); err != nil {
+			return err
+		}
+	}
+	if *toFlag != "" {
+		if params.To, err = time.Parse(dateLayout, *toFlag); err != nil {
+			return err
+		}
+	}
+
+	baseRules := eligibility.DefaultRuleset()
+	baseRules.Employment = appconf.Eligibility.Employment
+	candidateRules, err := eligibility.LoadRuleset(*rulesetFile, baseRules)
+	if err != nil {
+		return err
+	}
+	rates, err := fx.LoadFile(appconf.FX.RatesFile, appconf.FX.BaseCurrency)
+	if err != nil {
+		return err
+	}
+	var card *scorecard.Scorecard
+	if appconf.Scorecard.File != "" {
+		if card, err = scorecard.LoadFile(appconf.Scorecard.File); err != nil {
+			return err
+		}
+	}
+
+	s := NewService(NewRepository(db),
+		eligibility.NewEngine(baseRules, rates, card),
+		eligibility.NewEngine(candidateRules, rates, card),
+		candidateRules.Version)
+
+	report, err := s.Run(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return Write(w, report, *format)
+}
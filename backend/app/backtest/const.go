@@ -0,0 +1,16 @@
+package backtest
+
+const (
+	DefaultBatchSize = 500
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+const (
+	GroupTotal   = "total"
+	GroupPurpose = "purpose"
+	GroupReason  = "reason"
+)
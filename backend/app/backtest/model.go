@@ -0,0 +1,60 @@
+package backtest
+
+import "time"
+
+// Params selects the applications to replay, created in [From, To).
+type Params struct {
+	From      time.Time
+	To        time.Time
+	BatchSize int
+}
+
+// ======== sample report ======== //
+// {
+// 	"rulesetVersion": "candidate-example",
+// 	"from": "2025-01-01T00:00:00Z",
+// 	"to": "2026-01-01T00:00:00Z",
+// 	"total": {
+// 		"applications": 1200,
+// 		"baselineApproved": 540,
+// 		"candidateApproved": 498,
+// 		"baselineApprovalRate": "45.00",
+// 		"candidateApprovalRate": "41.50",
+// 		"approvedToDeclined": 61,
+// 		"declinedToApproved": 19
+// 	},
+// 	"byPurpose": [{"key": "car", ...}],
+// 	"byReason": [{"reason": "Loan amount cannot exceed 10 months of income", "approvedToDeclined": 48, "declinedToApproved": 0}],
+// 	"skipped": 0
+// }
+
+type Report struct {
+	RulesetVersion string        `json:"rulesetVersion"`
+	From           time.Time     `json:"from"`
+	To             time.Time     `json:"to"`
+	Total          Summary       `json:"total"`
+	ByPurpose      []Summary     `json:"byPurpose"`
+	ByReason       []ReasonFlips `json:"byReason"`
+	Skipped        int           `json:"skipped"`
+}
+
+// Summary compares the stored (baseline) outcome with the candidate's for a
+// group of applications. Rates are percentages with two decimals.
+type Summary struct {
+	Key                   string `json:"key,omitempty"`
+	Applications          int    `json:"applications"`
+	BaselineApproved      int    `json:"baselineApproved"`
+	CandidateApproved     int    `json:"candidateApproved"`
+	BaselineApprovalRate  string `json:"baselineApprovalRate"`
+	CandidateApprovalRate string `json:"candidateApprovalRate"`
+	ApprovedToDeclined    int    `json:"approvedToDeclined"`
+	DeclinedToApproved    int    `json:"declinedToApproved"`
+}
+
+// ReasonFlips counts flips by the reason that explains them: the candidate's
+// reason for new declines, the baseline reason for new approvals.
+type ReasonFlips struct {
+	Reason             string `json:"reason"`
+	ApprovedToDeclined int    `json:"approvedToDeclined"`
+	DeclinedToApproved int    `json:"declinedToApproved"`
+}
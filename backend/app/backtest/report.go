@@ -0,0 +1,60 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Write renders the report as indented JSON or as CSV.
+func Write(w io.Writer, report Report, format string) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case FormatCSV:
+		return writeCSV(w, report)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+// writeCSV writes one row per group: the total, each purpose and each flip
+// reason. Reason rows only carry flip counts.
+func writeCSV(w io.Writer, report Report) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{
+		"group", "key", "applications",
+		"baseline_approved", "candidate_approved",
+		"baseline_approval_rate", "candidate_approval_rate",
+		"approved_to_declined", "declined_to_approved",
+	}}
+
+	rows = append(rows, summaryRow(GroupTotal, report.Total))
+	for _, v := range report.ByPurpose {
+		rows = append(rows, summaryRow(GroupPurpose, v))
+	}
+	for _, v := range report.ByReason {
+		rows = append(rows, []string{
+			GroupReason, v.Reason, "", "", "", "", "",
+			strconv.Itoa(v.ApprovedToDeclined), strconv.Itoa(v.DeclinedToApproved),
+		})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func summaryRow(group string, s Summary) []string {
+	return []string{
+		group, s.Key, strconv.Itoa(s.Applications),
+		strconv.Itoa(s.BaselineApproved), strconv.Itoa(s.CandidateApproved),
+		s.BaselineApprovalRate, s.CandidateApprovalRate,
+		strconv.Itoa(s.ApprovedToDeclined), strconv.Itoa(s.DeclinedToApproved),
+	}
+}
@@ -0,0 +1,82 @@
+package backtest
+
+import (
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetLoanApplications(ctx context.Context, from time.Time, to time.Time, after *LoanApplicationEntity, limit int) ([]LoanApplicationEntity, error)
+	GetLoanObligations(ctx context.Context, applicationId string) ([]LoanObligationEntity, error)
+	GetLoanCoApplicants(ctx context.Context, applicationId string) ([]LoanCoApplicantEntity, error)
+	GetCreditReport(ctx context.Context, applicationId string) (*CreditReportEntity, error)
+}
+
+type RepositoryImpl struct {
+	queries *store.Queries
+}
+
+func NewRepository(db *sqlx.DB) Repository {
+	return &RepositoryImpl{
+		queries: store.New(db),
+	}
+}
+
+func (r *RepositoryImpl) GetLoanApplications(ctx context.Context, from time.Time, to time.Time, after *LoanApplicationEntity, limit int) ([]LoanApplicationEntity, error) {
+
+	applications, err := r.queries.ScanLoanApplications(ctx, store.ScanLoanApplicationsParams{
+		From:  from,
+		To:    to,
+		Limit: limit,
+		After: after,
+	})
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+func (r *RepositoryImpl) GetLoanObligations(ctx context.Context, applicationId string) ([]LoanObligationEntity, error) {
+
+	obligations, err := r.queries.ListLoanObligations(ctx, applicationId)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return obligations, nil
+}
+
+func (r *RepositoryImpl) GetLoanCoApplicants(ctx context.Context, applicationId string) ([]LoanCoApplicantEntity, error) {
+
+	coApplicants, err := r.queries.ListLoanCoApplicants(ctx, applicationId)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return coApplicants, nil
+}
+
+// GetCreditReport returns nil when no report was stored for the application.
+func (r *RepositoryImpl) GetCreditReport(ctx context.Context, applicationId string) (*CreditReportEntity, error) {
+
+	report, err := r.queries.GetCreditReport(ctx, applicationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,11 @@
+package backtest
+
+import "backend-loan-pre-approval/pkg/store"
+
+type LoanApplicationEntity = store.LoanApplication
+
+type LoanObligationEntity = store.LoanObligation
+
+type LoanCoApplicantEntity = store.LoanCoApplicant
+
+type CreditReportEntity = store.CreditReport
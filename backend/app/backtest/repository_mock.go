@@ -0,0 +1,38 @@
+package backtest
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepo struct {
+	mock.Mock
+}
+
+// Helper function to create a new repository with mocks
+func NewMockRepo() *MockRepo {
+
+	return &MockRepo{}
+}
+
+func (m *MockRepo) GetLoanApplications(ctx context.Context, from time.Time, to time.Time, after *LoanApplicationEntity, limit int) ([]LoanApplicationEntity, error) {
+	args := m.Called(ctx, from, to, after, limit)
+	return args.Get(0).([]LoanApplicationEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetLoanObligations(ctx context.Context, applicationId string) ([]LoanObligationEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).([]LoanObligationEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetLoanCoApplicants(ctx context.Context, applicationId string) ([]LoanCoApplicantEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).([]LoanCoApplicantEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetCreditReport(ctx context.Context, applicationId string) (*CreditReportEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).(*CreditReportEntity), args.Error(1)
+}
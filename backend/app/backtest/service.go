@@ -0,0 +1,218 @@
+package backtest
+
+import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/pkg/creditbureau"
+	"backend-loan-pre-approval/pkg/money"
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"encoding/json"
+	"log"
+	"math/big"
+	"sort"
+)
+
+type Service interface {
+	Run(ctx context.Context, params Params) (Report, error)
+}
+
+type ServiceImpl struct {
+	repository Repository
+	baseline   *eligibility.Engine
+	candidate  *eligibility.Engine
+	version    string
+}
+
+// NewService compares stored decisions with candidate, an engine built from
+// the ruleset under test. baseline only decides applications stored before
+// decisions were persisted.
+func NewService(repository Repository, baseline *eligibility.Engine, candidate *eligibility.Engine, candidateVersion string) Service {
+	return &ServiceImpl{
+		repository: repository,
+		baseline:   baseline,
+		candidate:  candidate,
+		version:    candidateVersion,
+	}
+}
+
+// Run replays every application in the range through the candidate engine,
+// at the time it was originally decided so the same exchange rates apply.
+func (s *ServiceImpl) Run(ctx context.Context, params Params) (Report, error) {
+	if params.BatchSize <= 0 {
+		params.BatchSize = DefaultBatchSize
+	}
+
+	report := Report{
+		RulesetVersion: s.version,
+		From:           params.From,
+		To:             params.To,
+	}
+	purposes := map[string]*Summary{}
+	reasons := map[string]*ReasonFlips{}
+
+	var after *LoanApplicationEntity
+	for {
+		batch, err := s.repository.GetLoanApplications(ctx, params.From, params.To, after, params.BatchSize)
+		if err != nil {
+			return Report{}, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, application := range batch {
+			baseline, candidate, err := s.replay(ctx, application)
+			if err != nil {
+				log.Printf("skipping application %s: %v", application.ApplicationId, err)
+				report.Skipped++
+				continue
+			}
+
+			purpose, ok := purposes[application.LoanPurpose]
+			if !ok {
+				purpose = &Summary{Key: application.LoanPurpose}
+				purposes[application.LoanPurpose] = purpose
+			}
+			report.Total.add(baseline.Eligible, candidate.Eligible)
+			purpose.add(baseline.Eligible, candidate.Eligible)
+
+			switch {
+			case baseline.Eligible && !candidate.Eligible:
+				reasonFlips(reasons, candidate.Reason).ApprovedToDeclined++
+			case !baseline.Eligible && candidate.Eligible:
+				reasonFlips(reasons, baseline.Reason).DeclinedToApproved++
+			}
+		}
+
+		after = &batch[len(batch)-1]
+	}
+
+	report.Total.finish()
+	report.ByPurpose = []Summary{}
+	for _, v := range purposes {
+		v.finish()
+		report.ByPurpose = append(report.ByPurpose, *v)
+	}
+	sort.Slice(report.ByPurpose, func(i, j int) bool {
+		return report.ByPurpose[i].Key < report.ByPurpose[j].Key
+	})
+
+	report.ByReason = []ReasonFlips{}
+	for _, v := range reasons {
+		report.ByReason = append(report.ByReason, *v)
+	}
+	sort.Slice(report.ByReason, func(i, j int) bool {
+		a, b := report.ByReason[i], report.ByReason[j]
+		if a.ApprovedToDeclined+a.DeclinedToApproved != b.ApprovedToDeclined+b.DeclinedToApproved {
+			return a.ApprovedToDeclined+a.DeclinedToApproved > b.ApprovedToDeclined+b.DeclinedToApproved
+		}
+		return a.Reason < b.Reason
+	})
+
+	return report, nil
+}
+
+// replay returns the stored decision and the candidate's decision for the
+// same applicant. Product overrides recorded with the stored decision apply
+// to the candidate too.
+func (s *ServiceImpl) replay(ctx context.Context, application LoanApplicationEntity) (eligibility.Decision, eligibility.Decision, error) {
+
+	obligations, err := s.repository.GetLoanObligations(ctx, application.ApplicationId)
+	if err != nil {
+		return eligibility.Decision{}, eligibility.Decision{}, err
+	}
+	existing := []money.Money{}
+	for _, v := range obligations {
+		existing = append(existing, v.Payment())
+	}
+	coApplicants, err := s.repository.GetLoanCoApplicants(ctx, application.ApplicationId)
+	if err != nil {
+		return eligibility.Decision{}, eligibility.Decision{}, err
+	}
+
+	stored, err := s.repository.GetCreditReport(ctx, application.ApplicationId)
+	if err != nil {
+		return eligibility.Decision{}, eligibility.Decision{}, err
+	}
+	var creditReport *creditbureau.Report
+	if stored != nil {
+		r := stored.ToReport()
+		creditReport = &r
+	}
+
+	applicant := eligibility.Applicant{
+		MonthlyIncome:  application.Income(),
+		LoanAmount:     application.Loan(),
+		LoanPurpose:    application.LoanPurpose,
+		DateOfBirth:    application.DateOfBirth.Time,
+		Age:            application.Age,
+		EmploymentType: application.EmploymentType,
+		TenureMonths:   application.EmploymentTenureMonths,
+		TermMonths:     application.TermMonths,
+		AnnualRateBps:  application.InterestRateBps,
+
+		ExistingObligations: existing,
+		CoBorrowerIncomes:   store.CoBorrowerIncomes(coApplicants),
+		CreditReport:        creditReport,
+	}
+
+	var baseline eligibility.Decision
+	if application.Decision.Valid {
+		if err := json.Unmarshal(application.Decision.JSONText, &baseline); err != nil {
+			return eligibility.Decision{}, eligibility.Decision{}, err
+		}
+		// A manual override or accepted offer replaces the automated
+		// outcome, so compare against what the applicant actually got.
+		baseline.Eligible = application.Eligible.Bool
+		baseline.Reason = application.Reason.String
+	} else {
+		if baseline, err = s.baseline.Evaluate(applicant, eligibility.RuleOverrides{}, application.Timestamp); err != nil {
+			return eligibility.Decision{}, eligibility.Decision{}, err
+		}
+	}
+
+	candidate, err := s.candidate.Evaluate(applicant, baseline.Overrides, application.Timestamp)
+	if err != nil {
+		return eligibility.Decision{}, eligibility.Decision{}, err
+	}
+
+	return baseline, candidate, nil
+}
+
+func (s *Summary) add(baseline bool, candidate bool) {
+	s.Applications++
+	if baseline {
+		s.BaselineApproved++
+	}
+	if candidate {
+		s.CandidateApproved++
+	}
+	if baseline && !candidate {
+		s.ApprovedToDeclined++
+	}
+	if !baseline && candidate {
+		s.DeclinedToApproved++
+	}
+}
+
+func (s *Summary) finish() {
+	s.BaselineApprovalRate = rate(s.BaselineApproved, s.Applications)
+	s.CandidateApprovalRate = rate(s.CandidateApproved, s.Applications)
+}
+
+func reasonFlips(reasons map[string]*ReasonFlips, reason string) *ReasonFlips {
+	if v, ok := reasons[reason]; ok {
+		return v
+	}
+	v := &ReasonFlips{Reason: reason}
+	reasons[reason] = v
+	return v
+}
+
+// rate renders n/total as a percentage with two decimals, e.g. "45.25".
+func rate(n int, total int) string {
+	if total == 0 {
+		return "0.00"
+	}
+	return new(big.Rat).SetFrac64(int64(n)*100, int64(total)).FloatString(2)
+}
@@ -0,0 +1,84 @@
+package backtest
+
+import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/pkg/fx"
+	"backend-loan-pre-approval/pkg/money"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+func newApplication(id string, purpose string, income int64, loan int64) LoanApplicationEntity {
+	return LoanApplicationEntity{
+		ApplicationId:         id,
+		MonthlyIncome:         money.FromMajor(income, money.DefaultCurrency).Amount,
+		MonthlyIncomeCurrency: money.DefaultCurrency,
+		LoanAmount:            money.FromMajor(loan, money.DefaultCurrency).Amount,
+		LoanAmountCurrency:    money.DefaultCurrency,
+		LoanPurpose:           purpose,
+		Age:                   30,
+		Timestamp:             time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func Test_Run_CountsFlipsByReasonAndPurpose(t *testing.T) {
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	candidateRules := eligibility.DefaultRuleset()
+	candidateRules.Version = "candidate"
+	candidateRules.MaxIncomeMultiple = 10
+
+	// Stored as approved under base-1; 11x income fails the candidate.
+	stored := newApplication("app-1", "car", 20000, 220000)
+	decision, _ := json.Marshal(eligibility.Decision{Eligible: true, Reason: eligibility.MsgEligibleUnderBaseRules})
+	stored.Decision = types.NullJSONText{JSONText: decision, Valid: true}
+	stored.Eligible = sql.NullBool{Bool: true, Valid: true}
+	stored.Reason = sql.NullString{String: eligibility.MsgEligibleUnderBaseRules, Valid: true}
+
+	// Legacy row without a stored decision passes both rulesets.
+	legacy := newApplication("app-2", "home", 20000, 100000)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo := NewMockRepo()
+	mockRepo.On("GetLoanApplications", mock.Anything, from, to, (*LoanApplicationEntity)(nil), 2).Return([]LoanApplicationEntity{stored, legacy}, nil)
+	mockRepo.On("GetLoanApplications", mock.Anything, from, to, mock.Anything, 2).Return([]LoanApplicationEntity{}, nil)
+	mockRepo.On("GetLoanObligations", mock.Anything, mock.Anything).Return([]LoanObligationEntity{}, nil)
+	mockRepo.On("GetLoanCoApplicants", mock.Anything, mock.Anything).Return([]LoanCoApplicantEntity{}, nil)
+	mockRepo.On("GetCreditReport", mock.Anything, mock.Anything).Return((*CreditReportEntity)(nil), nil)
+
+	s := NewService(mockRepo,
+		eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil),
+		eligibility.NewEngine(candidateRules, rates, nil),
+		candidateRules.Version)
+
+	report, err := s.Run(context.Background(), Params{From: from, To: to, BatchSize: 2})
+	assert.NilError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, report.Total.Applications)
+	assert.Equal(t, "100.00", report.Total.BaselineApprovalRate)
+	assert.Equal(t, "50.00", report.Total.CandidateApprovalRate)
+	assert.Equal(t, 1, report.Total.ApprovedToDeclined)
+	assert.Equal(t, "car", report.ByPurpose[0].Key)
+	assert.Equal(t, 1, report.ByPurpose[0].ApprovedToDeclined)
+	assert.Equal(t, 0, report.ByPurpose[1].ApprovedToDeclined)
+	assert.DeepEqual(t, []ReasonFlips{{Reason: "Loan amount cannot exceed 10 months of income", ApprovedToDeclined: 1}}, report.ByReason)
+
+	var out bytes.Buffer
+	assert.NilError(t, Write(&out, report, FormatCSV))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+
+	// Assert
+	assert.Equal(t, 5, len(lines))
+	assert.Equal(t, "total,,2,2,1,100.00,50.00,1,0", lines[1])
+	assert.Equal(t, "reason,Loan amount cannot exceed 10 months of income,,,,,,1,0", lines[4])
+}
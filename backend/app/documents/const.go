@@ -0,0 +1,32 @@
+package documents
+
+import "errors"
+
+const (
+	MsgInvalidBody   = "Invalid request body"
+	MsgUploadFailed  = "Document could not be uploaded"
+	MsgRequestFailed = "Document request failed"
+)
+
+const (
+	DefaultMaxSizeBytes = 10 << 20
+	MaxFileNameRunes    = 255
+	ScanBatchSize       = 20
+)
+
+var DocumentTypes = []string{"payslip", "idCard", "bankStatement", "other"}
+
+// ContentTypes are the accepted file types, as detected from the content.
+var ContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+var (
+	ErrApplicationNotFound    = errors.New("Loan application not found")
+	ErrDocumentNotFound       = errors.New("Document not found")
+	ErrDocumentEmpty          = errors.New("Document is empty")
+	ErrDocumentTooLarge       = errors.New("Document is too large")
+	ErrUnsupportedContentType = errors.New("Document must be a PDF, JPEG or PNG file")
+	ErrOfficerRequired        = errors.New("An authenticated officer is required")
+	ErrAccessDenied           = errors.New("Documents can only be read by the officer holding the application's claim")
+	ErrDocumentQuarantined    = errors.New("Document is awaiting its virus scan")
+	ErrDocumentInfected       = errors.New("Document failed its virus scan")
+)
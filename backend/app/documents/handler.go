@@ -0,0 +1,149 @@
+package documents
+
+import (
+	"backend-loan-pre-approval/pkg/officerauth"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is allowed on top of the file for the form's other
+// fields and part headers.
+const multipartOverhead = 64 << 10
+
+type Handler struct {
+	service      Service
+	maxSizeBytes int64
+}
+
+func NewHandler(service Service, maxSizeBytes int64) *Handler {
+	return &Handler{service: service, maxSizeBytes: maxSizeBytes}
+}
+
+func (h *Handler) UploadDocument(c *gin.Context) {
+
+	applicationId := c.Param("applicationId")
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSizeBytes+multipartOverhead)
+
+	// The file is read first: a body over the limit fails here, and
+	// PostForm would hide the error.
+	file, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, HttpBadResponse{Message: MsgUploadFailed, Reason: h.tooLargeReason()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, HttpBadResponse{Message: MsgInvalidBody, Reason: "missing required fields: file"})
+		return
+	}
+
+	documentType := strings.TrimSpace(c.PostForm("type"))
+	if documentType == "" {
+		c.JSON(http.StatusBadRequest, HttpBadResponse{Message: MsgInvalidBody, Reason: "missing required fields: type"})
+		return
+	}
+	if !isOneOf(documentType, DocumentTypes) {
+		c.JSON(http.StatusBadRequest, HttpBadResponse{Message: MsgInvalidBody, Reason: "Type must be one of: " + strings.Join(DocumentTypes, ", ")})
+		return
+	}
+	if file.Size > h.maxSizeBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, HttpBadResponse{Message: MsgUploadFailed, Reason: h.tooLargeReason()})
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		log.Println("err: ", err)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	defer f.Close()
+
+	document, duplicate, err := h.service.UploadDocument(c.Request.Context(), applicationId, Upload{
+		Type:     documentType,
+		FileName: file.Filename,
+		Body:     f,
+	})
+	if err != nil {
+		log.Println("err: ", err)
+		switch {
+		case errors.Is(err, ErrApplicationNotFound):
+			c.JSON(http.StatusNotFound, HttpBadResponse{Message: MsgUploadFailed, Reason: err.Error()})
+		case errors.Is(err, ErrDocumentTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, HttpBadResponse{Message: MsgUploadFailed, Reason: h.tooLargeReason()})
+		case errors.Is(err, ErrUnsupportedContentType):
+			c.JSON(http.StatusUnsupportedMediaType, HttpBadResponse{Message: MsgUploadFailed, Reason: err.Error()})
+		case errors.Is(err, ErrDocumentEmpty):
+			c.JSON(http.StatusBadRequest, HttpBadResponse{Message: MsgUploadFailed, Reason: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+		return
+	}
+
+	// A file the application already has is answered with the existing
+	// document rather than created again.
+	if duplicate {
+		c.JSON(http.StatusOK, document)
+		return
+	}
+	c.JSON(http.StatusCreated, document)
+}
+
+func (h *Handler) GetDocuments(c *gin.Context) {
+
+	applicationId := c.Param("applicationId")
+
+	documents, err := h.service.GetDocuments(c.Request.Context(), applicationId, officerauth.OfficerId(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GetDocumentsResponse{Documents: documents})
+}
+
+func (h *Handler) DownloadDocument(c *gin.Context) {
+
+	applicationId := c.Param("applicationId")
+	documentId := c.Param("documentId")
+
+	document, body, err := h.service.OpenDocument(c.Request.Context(), applicationId, documentId, officerauth.OfficerId(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer body.Close()
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, document.SizeBytes, document.ContentType, body, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}),
+	})
+}
+
+func (h *Handler) tooLargeReason() string {
+	return fmt.Sprintf("%s; the limit is %d bytes", ErrDocumentTooLarge.Error(), h.maxSizeBytes)
+}
+
+func respondError(c *gin.Context, err error) {
+	log.Println("err: ", err)
+	switch {
+	case errors.Is(err, ErrOfficerRequired):
+		c.JSON(http.StatusUnauthorized, HttpBadResponse{Message: MsgRequestFailed, Reason: err.Error()})
+	case errors.Is(err, ErrAccessDenied):
+		c.JSON(http.StatusForbidden, HttpBadResponse{Message: MsgRequestFailed, Reason: err.Error()})
+	case errors.Is(err, ErrDocumentNotFound):
+		c.JSON(http.StatusNotFound, HttpBadResponse{Message: MsgRequestFailed, Reason: err.Error()})
+	case errors.Is(err, ErrDocumentQuarantined), errors.Is(err, ErrDocumentInfected):
+		c.JSON(http.StatusConflict, HttpBadResponse{Message: MsgRequestFailed, Reason: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	}
+}
@@ -0,0 +1,269 @@
+package documents
+
+import (
+	"backend-loan-pre-approval/pkg/blobstore"
+	"backend-loan-pre-approval/pkg/officerauth"
+	"backend-loan-pre-approval/pkg/store"
+	"backend-loan-pre-approval/pkg/virusscan"
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+var samplePdf = []byte("%PDF-1.7\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n")
+
+func newTestRouter(t *testing.T, mockRepo *MockRepo, maxSizeBytes int64) (*gin.Engine, *blobstore.FileStore) {
+	blobs, err := blobstore.NewFileStore(t.TempDir())
+	assert.NilError(t, err)
+
+	s := NewService(mockRepo, blobs, virusscan.Fake{}, maxSizeBytes).(*ServiceImpl)
+	s.now = func() time.Time { return testNow }
+	h := NewHandler(s, maxSizeBytes)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loans/:applicationId/documents", h.UploadDocument)
+	tokens, err := officerauth.ParseTokens("officer-17:token-17,officer-9:token-9")
+	assert.NilError(t, err)
+	officer := r.Group("", officerauth.Required(tokens))
+	officer.GET("/api/v1/loans/:applicationId/documents", h.GetDocuments)
+	officer.GET("/api/v1/loans/:applicationId/documents/:documentId", h.DownloadDocument)
+	return r, blobs
+}
+
+func upload(r *gin.Engine, documentType string, fileName string, content []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("type", documentType)
+	part, _ := form.CreateFormFile("file", fileName)
+	part.Write(content)
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loans/app-1/documents", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	return resp
+}
+
+func get(r *gin.Engine, path string, officerId string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "http://0.0.0.0"+path, nil)
+	if officerId != "" {
+		req.Header.Set("Authorization", "Bearer token-"+strings.TrimPrefix(officerId, "officer-"))
+	}
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	return resp
+}
+
+func claimedItem(officer string, expiresAt time.Time) UnderwritingItemEntity {
+	return UnderwritingItemEntity{
+		ApplicationId:  "app-1",
+		Status:         "claimed",
+		ClaimedBy:      sql.NullString{String: officer, Valid: true},
+		ClaimExpiresAt: sql.NullTime{Time: expiresAt, Valid: true},
+	}
+}
+
+func Test_UploadDocument_SUCCESS(t *testing.T) {
+	mockRepo := NewMockRepo()
+	r, blobs := newTestRouter(t, mockRepo, DefaultMaxSizeBytes)
+
+	sum := sha256.Sum256(samplePdf)
+	digest := hex.EncodeToString(sum[:])
+
+	mockRepo.On("GetLoanApplication", mock.Anything, "app-1").Return(LoanApplicationEntity{ApplicationId: "app-1"}, nil)
+	mockRepo.On("GetLoanDocumentBySha256", mock.Anything, "app-1", digest).Return(LoanDocumentEntity{}, ErrDocumentNotFound)
+	mockRepo.On("CreateLoanDocument", mock.Anything, mock.Anything).Return(true, nil)
+
+	resp := upload(r, "payslip", `C:\Users\somkanit\payslip-2025-06.pdf`, samplePdf)
+
+	var response Document
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+	stored := mockRepo.Calls[2].Arguments.Get(1).(LoanDocumentEntity)
+	blob, err := blobs.Get(t.Context(), stored.StorageKey)
+	assert.NilError(t, err)
+	content, _ := io.ReadAll(blob)
+	blob.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Equal(t, "payslip-2025-06.pdf", response.FileName)
+	assert.Equal(t, "application/pdf", response.ContentType)
+	assert.Equal(t, digest, response.Sha256)
+	assert.Equal(t, int64(len(samplePdf)), response.SizeBytes)
+	assert.Equal(t, store.DocumentQuarantined, response.ScanStatus)
+	assert.Equal(t, "documents/"+digest[:2]+"/"+digest, stored.StorageKey)
+	assert.DeepEqual(t, samplePdf, content)
+}
+
+func Test_UploadDocument_Duplicate(t *testing.T) {
+	mockRepo := NewMockRepo()
+	r, _ := newTestRouter(t, mockRepo, DefaultMaxSizeBytes)
+
+	existing := LoanDocumentEntity{DocumentId: "doc-1", ApplicationId: "app-1", DocumentType: "payslip", FileName: "payslip.pdf"}
+	mockRepo.On("GetLoanApplication", mock.Anything, "app-1").Return(LoanApplicationEntity{ApplicationId: "app-1"}, nil)
+	mockRepo.On("GetLoanDocumentBySha256", mock.Anything, "app-1", mock.Anything).Return(existing, nil)
+
+	resp := upload(r, "payslip", "payslip-copy.pdf", samplePdf)
+
+	var response Document
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "doc-1", response.DocumentId)
+	mockRepo.AssertNotCalled(t, "CreateLoanDocument", mock.Anything, mock.Anything)
+}
+
+func Test_UploadDocument_Validation(t *testing.T) {
+	mockRepo := NewMockRepo()
+	r, _ := newTestRouter(t, mockRepo, 1024)
+
+	mockRepo.On("GetLoanApplication", mock.Anything, "app-1").Return(LoanApplicationEntity{ApplicationId: "app-1"}, nil)
+
+	// A script renamed to .pdf is rejected on its content.
+	disguised := upload(r, "payslip", "payslip.pdf", []byte("#!/bin/sh\nrm -rf /\n"))
+	unknownType := upload(r, "selfie", "payslip.pdf", samplePdf)
+	tooLarge := upload(r, "payslip", "payslip.pdf", append(append([]byte{}, samplePdf...), make([]byte, 2048)...))
+	empty := upload(r, "payslip", "payslip.pdf", nil)
+
+	// Assert
+	assert.Equal(t, http.StatusUnsupportedMediaType, disguised.Code)
+	assert.Equal(t, http.StatusBadRequest, unknownType.Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, tooLarge.Code)
+	assert.Equal(t, http.StatusBadRequest, empty.Code)
+}
+
+func Test_UploadDocument_ApplicationNotFound(t *testing.T) {
+	mockRepo := NewMockRepo()
+	r, _ := newTestRouter(t, mockRepo, DefaultMaxSizeBytes)
+
+	mockRepo.On("GetLoanApplication", mock.Anything, "app-1").Return(LoanApplicationEntity{}, ErrApplicationNotFound)
+
+	resp := upload(r, "payslip", "payslip.pdf", samplePdf)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func Test_GetDocuments_Authorization(t *testing.T) {
+	mockRepo := NewMockRepo()
+	r, _ := newTestRouter(t, mockRepo, DefaultMaxSizeBytes)
+
+	mockRepo.On("GetUnderwritingItem", mock.Anything, "app-1").Return(claimedItem("officer-17", testNow.Add(time.Minute)), nil)
+	mockRepo.On("GetUnderwritingItem", mock.Anything, "app-2").Return(claimedItem("officer-17", testNow.Add(-time.Minute)), nil)
+	mockRepo.On("GetLoanDocuments", mock.Anything, "app-1").Return([]LoanDocumentEntity{{DocumentId: "doc-1", ApplicationId: "app-1"}}, nil)
+
+	anonymous := get(r, "/api/v1/loans/app-1/documents", "")
+	otherOfficer := get(r, "/api/v1/loans/app-1/documents", "officer-9")
+	expiredClaim := get(r, "/api/v1/loans/app-2/documents", "officer-17")
+	holder := get(r, "/api/v1/loans/app-1/documents", "officer-17")
+
+	var response GetDocumentsResponse
+	if err := json.Unmarshal(holder.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, anonymous.Code)
+	assert.Equal(t, http.StatusForbidden, otherOfficer.Code)
+	assert.Equal(t, http.StatusForbidden, expiredClaim.Code)
+	assert.Equal(t, http.StatusOK, holder.Code)
+	assert.Equal(t, 1, len(response.Documents))
+}
+
+func Test_DownloadDocument(t *testing.T) {
+	mockRepo := NewMockRepo()
+	r, blobs := newTestRouter(t, mockRepo, DefaultMaxSizeBytes)
+
+	assert.NilError(t, blobs.Put(t.Context(), "documents/ab/abcdef", bytes.NewReader(samplePdf), int64(len(samplePdf)), "application/pdf"))
+
+	mockRepo.On("GetUnderwritingItem", mock.Anything, "app-1").Return(claimedItem("officer-17", testNow.Add(time.Minute)), nil)
+	mockRepo.On("GetLoanDocument", mock.Anything, "app-1", "doc-1").Return(LoanDocumentEntity{
+		DocumentId:    "doc-1",
+		ApplicationId: "app-1",
+		FileName:      "สลิปเงินเดือน.pdf",
+		ContentType:   "application/pdf",
+		SizeBytes:     int64(len(samplePdf)),
+		StorageKey:    "documents/ab/abcdef",
+		ScanStatus:    store.DocumentClean,
+	}, nil)
+	mockRepo.On("GetLoanDocument", mock.Anything, "app-1", "doc-2").Return(LoanDocumentEntity{}, ErrDocumentNotFound)
+	mockRepo.On("GetLoanDocument", mock.Anything, "app-1", "doc-3").Return(LoanDocumentEntity{DocumentId: "doc-3", StorageKey: "documents/ab/abcdef", ScanStatus: store.DocumentQuarantined}, nil)
+	mockRepo.On("GetLoanDocument", mock.Anything, "app-1", "doc-4").Return(LoanDocumentEntity{DocumentId: "doc-4", StorageKey: "documents/ab/abcdef", ScanStatus: store.DocumentInfected}, nil)
+
+	resp := get(r, "/api/v1/loans/app-1/documents/doc-1", "officer-17")
+	missing := get(r, "/api/v1/loans/app-1/documents/doc-2", "officer-17")
+	denied := get(r, "/api/v1/loans/app-1/documents/doc-1", "officer-9")
+	quarantined := get(r, "/api/v1/loans/app-1/documents/doc-3", "officer-17")
+	infected := get(r, "/api/v1/loans/app-1/documents/doc-4", "officer-17")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.DeepEqual(t, samplePdf, resp.Body.Bytes())
+	assert.Equal(t, "application/pdf", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename*=utf-8''%E0%B8%AA%E0%B8%A5%E0%B8%B4%E0%B8%9B%E0%B9%80%E0%B8%87%E0%B8%B4%E0%B8%99%E0%B9%80%E0%B8%94%E0%B8%B7%E0%B8%AD%E0%B8%99.pdf", resp.Header().Get("Content-Disposition"))
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	assert.Equal(t, http.StatusForbidden, denied.Code)
+	assert.Equal(t, http.StatusConflict, quarantined.Code)
+	assert.Equal(t, http.StatusConflict, infected.Code)
+}
+
+func Test_ScanQuarantined(t *testing.T) {
+	mockRepo := NewMockRepo()
+	blobs, err := blobstore.NewFileStore(t.TempDir())
+	assert.NilError(t, err)
+	s := NewService(mockRepo, blobs, virusscan.Fake{}, DefaultMaxSizeBytes).(*ServiceImpl)
+	s.now = func() time.Time { return testNow }
+
+	infectedPdf := append(append([]byte{}, samplePdf...), []byte(virusscan.EICAR)...)
+	assert.NilError(t, blobs.Put(t.Context(), "documents/aa/clean", bytes.NewReader(samplePdf), int64(len(samplePdf)), "application/pdf"))
+	assert.NilError(t, blobs.Put(t.Context(), "documents/bb/infected", bytes.NewReader(infectedPdf), int64(len(infectedPdf)), "application/pdf"))
+
+	mockRepo.On("GetQuarantinedLoanDocuments", mock.Anything, ScanBatchSize).Return([]LoanDocumentEntity{
+		{DocumentId: "doc-1", StorageKey: "documents/aa/clean", ScanStatus: store.DocumentQuarantined},
+		{DocumentId: "doc-2", StorageKey: "documents/bb/infected", ScanStatus: store.DocumentQuarantined},
+		{DocumentId: "doc-3", StorageKey: "documents/cc/missing", ScanStatus: store.DocumentQuarantined},
+	}, nil)
+	mockRepo.On("UpdateLoanDocumentScan", mock.Anything, mock.Anything).Return(true, nil)
+
+	scanned, err := s.ScanQuarantined(t.Context())
+	assert.NilError(t, err)
+
+	verdicts := map[string]LoanDocumentEntity{}
+	for _, c := range mockRepo.Calls {
+		if c.Method == "UpdateLoanDocumentScan" {
+			document := c.Arguments.Get(1).(LoanDocumentEntity)
+			verdicts[document.DocumentId] = document
+		}
+	}
+
+	// Assert
+	assert.Equal(t, 2, scanned)
+	assert.Equal(t, store.DocumentClean, verdicts["doc-1"].ScanStatus)
+	assert.Equal(t, testNow, verdicts["doc-1"].ScannedAt.Time)
+	assert.Equal(t, store.DocumentInfected, verdicts["doc-2"].ScanStatus)
+	assert.Equal(t, "Eicar-Test-Signature", verdicts["doc-2"].ScanSignature)
+	_, retried := verdicts["doc-3"]
+	assert.Equal(t, false, retried)
+}
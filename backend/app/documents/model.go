@@ -0,0 +1,63 @@
+package documents
+
+import (
+	"io"
+	"time"
+)
+
+// ======== sample upload request ======== //
+//
+//	POST /api/v1/loans/{applicationId}/documents
+//	Content-Type: multipart/form-data
+//
+//	type=payslip
+//	file=@payslip-2025-06.pdf
+//
+// type is one of DocumentTypes. The file's type is detected from its
+// content; the name and Content-Type sent with it are not trusted.
+
+// Upload is one file received for an application.
+type Upload struct {
+	Type     string
+	FileName string
+	Body     io.Reader
+}
+
+// ======== sample document ======== //
+//
+// A new document is quarantined until it has been scanned, then clean or
+// infected. Only clean documents can be downloaded.
+//
+//	{
+//		"documentId": "9b2f3c1e-4d5a-4f6b-8c7d-0e1f2a3b4c5d",
+//		"applicationId": "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+//		"type": "payslip",
+//		"fileName": "payslip-2025-06.pdf",
+//		"contentType": "application/pdf",
+//		"sizeBytes": 183204,
+//		"sha256": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+//		"uploadedAt": "2025-07-19T19:34:56+07:00",
+//		"scanStatus": "clean",
+//		"scannedAt": "2025-07-19T19:35:02+07:00"
+//	}
+type Document struct {
+	DocumentId    string     `json:"documentId"`
+	ApplicationId string     `json:"applicationId"`
+	Type          string     `json:"type"`
+	FileName      string     `json:"fileName"`
+	ContentType   string     `json:"contentType"`
+	SizeBytes     int64      `json:"sizeBytes"`
+	Sha256        string     `json:"sha256"`
+	UploadedAt    time.Time  `json:"uploadedAt"`
+	ScanStatus    string     `json:"scanStatus"`
+	ScannedAt     *time.Time `json:"scannedAt,omitempty"`
+}
+
+type GetDocumentsResponse struct {
+	Documents []Document `json:"documents"`
+}
+
+type HttpBadResponse struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
@@ -0,0 +1,138 @@
+package documents
+
+import (
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetLoanApplication(ctx context.Context, applicationId string) (LoanApplicationEntity, error)
+	GetUnderwritingItem(ctx context.Context, applicationId string) (UnderwritingItemEntity, error)
+	CreateLoanDocument(ctx context.Context, document LoanDocumentEntity) (bool, error)
+	GetLoanDocument(ctx context.Context, applicationId string, documentId string) (LoanDocumentEntity, error)
+	GetLoanDocumentBySha256(ctx context.Context, applicationId string, sha256 string) (LoanDocumentEntity, error)
+	GetLoanDocuments(ctx context.Context, applicationId string) ([]LoanDocumentEntity, error)
+	GetQuarantinedLoanDocuments(ctx context.Context, limit int) ([]LoanDocumentEntity, error)
+	UpdateLoanDocumentScan(ctx context.Context, document LoanDocumentEntity) (bool, error)
+}
+
+type RepositoryImpl struct {
+	queries *store.Queries
+}
+
+func NewRepository(db *sqlx.DB) Repository {
+	return &RepositoryImpl{
+		queries: store.New(db),
+	}
+}
+
+func (r *RepositoryImpl) GetLoanApplication(ctx context.Context, applicationId string) (LoanApplicationEntity, error) {
+
+	loanApplication, err := r.queries.GetLoanApplication(ctx, applicationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return LoanApplicationEntity{}, ErrApplicationNotFound
+		}
+		log.Println("err: ", err)
+		return LoanApplicationEntity{}, err
+	}
+
+	return loanApplication, nil
+}
+
+// GetUnderwritingItem returns ErrAccessDenied for an application that is not
+// queued, as no officer may read its documents.
+func (r *RepositoryImpl) GetUnderwritingItem(ctx context.Context, applicationId string) (UnderwritingItemEntity, error) {
+
+	item, err := r.queries.GetUnderwritingItem(ctx, applicationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UnderwritingItemEntity{}, ErrAccessDenied
+		}
+		log.Println("err: ", err)
+		return UnderwritingItemEntity{}, err
+	}
+
+	return item, nil
+}
+
+// CreateLoanDocument reports false when the application already has a
+// document with the same content.
+func (r *RepositoryImpl) CreateLoanDocument(ctx context.Context, document LoanDocumentEntity) (bool, error) {
+
+	inserted, err := r.queries.InsertLoanDocument(ctx, document)
+	if err != nil {
+		log.Println("err: ", err)
+		return false, err
+	}
+
+	return inserted, nil
+}
+
+func (r *RepositoryImpl) GetLoanDocument(ctx context.Context, applicationId string, documentId string) (LoanDocumentEntity, error) {
+
+	document, err := r.queries.GetLoanDocument(ctx, applicationId, documentId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return LoanDocumentEntity{}, ErrDocumentNotFound
+		}
+		log.Println("err: ", err)
+		return LoanDocumentEntity{}, err
+	}
+
+	return document, nil
+}
+
+func (r *RepositoryImpl) GetLoanDocumentBySha256(ctx context.Context, applicationId string, sha256 string) (LoanDocumentEntity, error) {
+
+	document, err := r.queries.GetLoanDocumentBySha256(ctx, applicationId, sha256)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return LoanDocumentEntity{}, ErrDocumentNotFound
+		}
+		log.Println("err: ", err)
+		return LoanDocumentEntity{}, err
+	}
+
+	return document, nil
+}
+
+func (r *RepositoryImpl) GetLoanDocuments(ctx context.Context, applicationId string) ([]LoanDocumentEntity, error) {
+
+	documents, err := r.queries.ListLoanDocuments(ctx, applicationId)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+func (r *RepositoryImpl) GetQuarantinedLoanDocuments(ctx context.Context, limit int) ([]LoanDocumentEntity, error) {
+
+	documents, err := r.queries.ListQuarantinedLoanDocuments(ctx, limit)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// UpdateLoanDocumentScan reports false when the document had already been
+// scanned.
+func (r *RepositoryImpl) UpdateLoanDocumentScan(ctx context.Context, document LoanDocumentEntity) (bool, error) {
+
+	updated, err := r.queries.UpdateLoanDocumentScan(ctx, document)
+	if err != nil {
+		log.Println("err: ", err)
+		return false, err
+	}
+
+	return updated, nil
+}
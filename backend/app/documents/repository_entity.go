@@ -0,0 +1,9 @@
+package documents
+
+import "backend-loan-pre-approval/pkg/store"
+
+type LoanApplicationEntity = store.LoanApplication
+
+type LoanDocumentEntity = store.LoanDocument
+
+type UnderwritingItemEntity = store.UnderwritingItem
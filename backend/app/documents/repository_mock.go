@@ -0,0 +1,57 @@
+package documents
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepo struct {
+	mock.Mock
+}
+
+// Helper function to create a new repository with mocks
+func NewMockRepo() *MockRepo {
+
+	return &MockRepo{}
+}
+
+func (m *MockRepo) GetLoanApplication(ctx context.Context, applicationId string) (LoanApplicationEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).(LoanApplicationEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetUnderwritingItem(ctx context.Context, applicationId string) (UnderwritingItemEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).(UnderwritingItemEntity), args.Error(1)
+}
+
+func (m *MockRepo) CreateLoanDocument(ctx context.Context, document LoanDocumentEntity) (bool, error) {
+	args := m.Called(ctx, document)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepo) GetLoanDocument(ctx context.Context, applicationId string, documentId string) (LoanDocumentEntity, error) {
+	args := m.Called(ctx, applicationId, documentId)
+	return args.Get(0).(LoanDocumentEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetLoanDocumentBySha256(ctx context.Context, applicationId string, sha256 string) (LoanDocumentEntity, error) {
+	args := m.Called(ctx, applicationId, sha256)
+	return args.Get(0).(LoanDocumentEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetLoanDocuments(ctx context.Context, applicationId string) ([]LoanDocumentEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).([]LoanDocumentEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetQuarantinedLoanDocuments(ctx context.Context, limit int) ([]LoanDocumentEntity, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]LoanDocumentEntity), args.Error(1)
+}
+
+func (m *MockRepo) UpdateLoanDocumentScan(ctx context.Context, document LoanDocumentEntity) (bool, error) {
+	args := m.Called(ctx, document)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,36 @@
+package documents
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StartScanner scans quarantined documents every interval until ctx is
+// done. An interval of 0 disables it, leaving new documents quarantined.
+func StartScanner(ctx context.Context, service Service, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				scanned, err := service.ScanQuarantined(ctx)
+				if err != nil {
+					log.Println("err: ", err)
+					continue
+				}
+				if scanned > 0 {
+					log.Printf("scanned %d quarantined documents", scanned)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,309 @@
+package documents
+
+import (
+	"backend-loan-pre-approval/pkg/blobstore"
+	"backend-loan-pre-approval/pkg/store"
+	"backend-loan-pre-approval/pkg/virusscan"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	UploadDocument(ctx context.Context, applicationId string, upload Upload) (Document, bool, error)
+	GetDocuments(ctx context.Context, applicationId string, officerId string) ([]Document, error)
+	OpenDocument(ctx context.Context, applicationId string, documentId string, officerId string) (Document, io.ReadCloser, error)
+	ScanQuarantined(ctx context.Context) (int, error)
+}
+
+type ServiceImpl struct {
+	repository   Repository
+	blobs        blobstore.BlobStore
+	scanner      virusscan.Scanner
+	maxSizeBytes int64
+	now          func() time.Time
+}
+
+// NewService builds the documents service. Files larger than maxSizeBytes
+// are rejected. scanner may be nil, in which case documents stay
+// quarantined and cannot be downloaded.
+func NewService(repository Repository, blobs blobstore.BlobStore, scanner virusscan.Scanner, maxSizeBytes int64) Service {
+	return &ServiceImpl{
+		repository:   repository,
+		blobs:        blobs,
+		scanner:      scanner,
+		maxSizeBytes: maxSizeBytes,
+		now:          time.Now,
+	}
+}
+
+// UploadDocument stores the file and records it on the application. A file
+// the application already has is not stored again; the existing document is
+// returned and reported as a duplicate.
+func (s *ServiceImpl) UploadDocument(ctx context.Context, applicationId string, upload Upload) (Document, bool, error) {
+
+	if _, err := s.repository.GetLoanApplication(ctx, applicationId); err != nil {
+		return Document{}, false, err
+	}
+
+	content, err := io.ReadAll(io.LimitReader(upload.Body, s.maxSizeBytes+1))
+	if err != nil {
+		return Document{}, false, err
+	}
+	if len(content) == 0 {
+		return Document{}, false, ErrDocumentEmpty
+	}
+	if int64(len(content)) > s.maxSizeBytes {
+		return Document{}, false, ErrDocumentTooLarge
+	}
+	contentType := detectContentType(content)
+	if !isOneOf(contentType, ContentTypes) {
+		return Document{}, false, ErrUnsupportedContentType
+	}
+
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+
+	existing, err := s.repository.GetLoanDocumentBySha256(ctx, applicationId, digest)
+	if err == nil {
+		return toDocument(existing), true, nil
+	}
+	if !errors.Is(err, ErrDocumentNotFound) {
+		return Document{}, false, err
+	}
+
+	// Blobs are keyed by content, so a file shared by several applications
+	// is stored once.
+	key := storageKey(digest)
+	stored, err := s.blobs.Exists(ctx, key)
+	if err != nil {
+		return Document{}, false, err
+	}
+	if !stored {
+		if err := s.blobs.Put(ctx, key, bytes.NewReader(content), int64(len(content)), contentType); err != nil {
+			return Document{}, false, err
+		}
+	}
+
+	document := LoanDocumentEntity{
+		DocumentId:    uuid.New().String(),
+		ApplicationId: applicationId,
+		DocumentType:  upload.Type,
+		FileName:      sanitizeFileName(upload.FileName),
+		ContentType:   contentType,
+		SizeBytes:     int64(len(content)),
+		Sha256:        digest,
+		StorageKey:    key,
+		UploadedAt:    s.now(),
+		ScanStatus:    store.DocumentQuarantined,
+	}
+	inserted, err := s.repository.CreateLoanDocument(ctx, document)
+	if err != nil {
+		return Document{}, false, err
+	}
+	if !inserted {
+		// The same file was uploaded concurrently and recorded first.
+		if existing, err = s.repository.GetLoanDocumentBySha256(ctx, applicationId, digest); err != nil {
+			return Document{}, false, err
+		}
+		return toDocument(existing), true, nil
+	}
+
+	return toDocument(document), false, nil
+}
+
+func (s *ServiceImpl) GetDocuments(ctx context.Context, applicationId string, officerId string) ([]Document, error) {
+
+	if err := s.authorize(ctx, applicationId, officerId); err != nil {
+		return []Document{}, err
+	}
+
+	result, err := s.repository.GetLoanDocuments(ctx, applicationId)
+	if err != nil {
+		return []Document{}, err
+	}
+
+	res := []Document{}
+	for _, v := range result {
+		res = append(res, toDocument(v))
+	}
+
+	return res, nil
+}
+
+// OpenDocument returns the content of a clean document. The caller closes
+// the reader.
+func (s *ServiceImpl) OpenDocument(ctx context.Context, applicationId string, documentId string, officerId string) (Document, io.ReadCloser, error) {
+
+	if err := s.authorize(ctx, applicationId, officerId); err != nil {
+		return Document{}, nil, err
+	}
+
+	document, err := s.repository.GetLoanDocument(ctx, applicationId, documentId)
+	if err != nil {
+		return Document{}, nil, err
+	}
+	switch document.ScanStatus {
+	case store.DocumentClean:
+	case store.DocumentInfected:
+		return Document{}, nil, ErrDocumentInfected
+	default:
+		return Document{}, nil, ErrDocumentQuarantined
+	}
+
+	body, err := s.blobs.Get(ctx, document.StorageKey)
+	if err != nil {
+		return Document{}, nil, err
+	}
+
+	return toDocument(document), body, nil
+}
+
+// ScanQuarantined scans up to ScanBatchSize quarantined documents and
+// returns how many were given a verdict. A document that could not be
+// scanned stays quarantined and is tried again on the next run.
+func (s *ServiceImpl) ScanQuarantined(ctx context.Context) (int, error) {
+
+	if s.scanner == nil {
+		return 0, nil
+	}
+
+	result, err := s.repository.GetQuarantinedLoanDocuments(ctx, ScanBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	scanned := 0
+	for _, v := range result {
+		if err := s.scan(ctx, v); err != nil {
+			log.Printf("err: scanning document %s: %v", v.DocumentId, err)
+			continue
+		}
+		scanned++
+	}
+
+	return scanned, nil
+}
+
+func (s *ServiceImpl) scan(ctx context.Context, document LoanDocumentEntity) error {
+	body, err := s.blobs.Get(ctx, document.StorageKey)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	result, err := s.scanner.Scan(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	document.ScanStatus = store.DocumentClean
+	if result.Infected {
+		document.ScanStatus = store.DocumentInfected
+		document.ScanSignature = result.Signature
+		log.Printf("document %s of application %s is infected: %s", document.DocumentId, document.ApplicationId, result.Signature)
+	}
+	document.ScannedAt = sql.NullTime{Time: s.now(), Valid: true}
+
+	_, err = s.repository.UpdateLoanDocumentScan(ctx, document)
+	return err
+}
+
+// authorize lets an officer read an application's documents while holding
+// its underwriting claim, and the officer who decided it afterwards.
+func (s *ServiceImpl) authorize(ctx context.Context, applicationId string, officerId string) error {
+	if officerId == "" {
+		return ErrOfficerRequired
+	}
+
+	item, err := s.repository.GetUnderwritingItem(ctx, applicationId)
+	if err != nil {
+		return err
+	}
+
+	switch item.Status {
+	case store.UnderwritingClaimed:
+		if item.ClaimedBy.String == officerId && item.ClaimExpiresAt.Time.After(s.now()) {
+			return nil
+		}
+	case store.UnderwritingDecided:
+		if item.DecidedBy.String == officerId {
+			return nil
+		}
+	}
+	return ErrAccessDenied
+}
+
+// detectContentType sniffs the type from the first bytes of the content,
+// dropping parameters such as "; charset=utf-8".
+func detectContentType(content []byte) string {
+	contentType := http.DetectContentType(content)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return contentType
+}
+
+func storageKey(digest string) string {
+	return "documents/" + digest[:2] + "/" + digest
+}
+
+// sanitizeFileName keeps the base name of what the client sent, without
+// control characters, for display and the download's Content-Disposition.
+func sanitizeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	if runes := []rune(name); len(runes) > MaxFileNameRunes {
+		name = string(runes[:MaxFileNameRunes])
+	}
+	if name == "" || name == "." || name == "/" {
+		return "document"
+	}
+	return name
+}
+
+func toDocument(e LoanDocumentEntity) Document {
+	document := Document{
+		DocumentId:    e.DocumentId,
+		ApplicationId: e.ApplicationId,
+		Type:          e.DocumentType,
+		FileName:      e.FileName,
+		ContentType:   e.ContentType,
+		SizeBytes:     e.SizeBytes,
+		Sha256:        e.Sha256,
+		UploadedAt:    e.UploadedAt,
+		ScanStatus:    e.ScanStatus,
+	}
+	if e.ScannedAt.Valid {
+		document.ScannedAt = &e.ScannedAt.Time
+	}
+	return document
+}
+
+func isOneOf(value string, values []string) bool {
+	for _, v := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package documents
+
+import (
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockService struct {
+	mock.Mock
+}
+
+func NewMockService() *MockService {
+	return &MockService{}
+}
+
+func (m *MockService) UploadDocument(ctx context.Context, applicationId string, upload Upload) (Document, bool, error) {
+	args := m.Called(ctx, applicationId, upload)
+	return args.Get(0).(Document), args.Bool(1), args.Error(2)
+}
+
+func (m *MockService) GetDocuments(ctx context.Context, applicationId string, officerId string) ([]Document, error) {
+	args := m.Called(ctx, applicationId, officerId)
+	return args.Get(0).([]Document), args.Error(1)
+}
+
+func (m *MockService) OpenDocument(ctx context.Context, applicationId string, documentId string, officerId string) (Document, io.ReadCloser, error) {
+	args := m.Called(ctx, applicationId, documentId, officerId)
+	body, _ := args.Get(1).(io.ReadCloser)
+	return args.Get(0).(Document), body, args.Error(2)
+}
+
+func (m *MockService) ScanQuarantined(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,44 @@
+package eligibility
+
+const (
+	MsgEligibleUnderBaseRules = "Eligible under base rules"
+	MsgReferredForReview      = "Referred for manual review"
+	// MsgReferredNoCreditReport refers applicants who pass every rule while
+	// the bureau is unreachable, so an outage does not decline them.
+	MsgReferredNoCreditReport = "Credit report is unavailable; referred for manual review"
+)
+
+const (
+	ErrMonthlyIncomeInsufficient = "Monthly income is insufficient"
+	ErrAgeNotInRange             = "Age not in range (must be between %d-%d)"
+	ErrAgeAtMaturityExceeded     = "Age at the end of the loan term cannot exceed %d"
+	ErrLoansNotSupported         = "%s loans not supported"
+	ErrLoanAmountExceedsCap      = "Loan amount cannot exceed %d months of income"
+	ErrDebtServiceRatioExceeded  = "Debt service ratio %s%% exceeds the maximum of %s%%"
+	ErrTenureTooShort            = "Employment tenure must be at least %d months for %s applicants"
+	ErrCreditScoreTooLow         = "Credit score is below the minimum of %d"
+	ErrRiskGradeDeclined         = "Risk grade %s does not meet the approval cutoff"
+)
+
+const (
+	RuleMinMonthlyIncome = "minMonthlyIncome"
+	RuleAgeRange         = "ageRange"
+	RuleAgeAtMaturity    = "ageAtMaturity"
+	RulePurpose          = "purpose"
+	RuleIncomeMultiple   = "incomeMultiple"
+	RuleDebtServiceRatio = "debtServiceRatio"
+	RuleCreditScore      = "creditScore"
+	RuleEmploymentTenure = "employmentTenure"
+)
+
+// Scorecard characteristics the engine supplies. Amounts are whole units of
+// the base currency and debtServiceRatio is in percent.
+const (
+	ScoreMonthlyIncome    = "monthlyIncome"
+	ScoreAge              = "age"
+	ScorePurpose          = "purpose"
+	ScoreLoanToIncome     = "loanToIncome"
+	ScoreDebtServiceRatio = "debtServiceRatio"
+	ScoreCreditScore      = "creditScore"
+	ScoreEmploymentType   = "employmentType"
+)
@@ -0,0 +1,183 @@
+package eligibility
+
+import (
+	"backend-loan-pre-approval/pkg/amortization"
+	"backend-loan-pre-approval/pkg/birthdate"
+	"backend-loan-pre-approval/pkg/creditbureau"
+	"backend-loan-pre-approval/pkg/fx"
+	"backend-loan-pre-approval/pkg/money"
+	"backend-loan-pre-approval/pkg/scorecard"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+type Engine struct {
+	ruleset Ruleset
+	rates   *fx.Table
+	card    *scorecard.Scorecard
+}
+
+// NewEngine builds an engine. card may be nil, in which case every
+// application that passes the rules is approved.
+func NewEngine(ruleset Ruleset, rates *fx.Table, card *scorecard.Scorecard) *Engine {
+	return &Engine{
+		ruleset: ruleset,
+		rates:   rates,
+		card:    card,
+	}
+}
+
+func (e *Engine) BaseCurrency() string {
+	return e.rates.Base()
+}
+
+// Normalize converts m into the base currency with the rate in effect at the
+// given time.
+func (e *Engine) Normalize(m money.Money, at time.Time) (money.Money, fx.AppliedRate, error) {
+	return e.rates.ToBase(m, at)
+}
+
+// Evaluate normalizes the applicant's amounts at decision time and runs every
+// base rule, with overrides applied on top of the engine's ruleset. It only
+// fails when an amount cannot be converted or repaid over the term.
+func (e *Engine) Evaluate(a Applicant, overrides RuleOverrides, at time.Time) (Decision, error) {
+
+	ruleset := e.ruleset.Apply(overrides)
+	decision := Decision{
+		RulesetVersion: e.ruleset.Version,
+		Overrides:      overrides,
+		BaseCurrency:   e.rates.Base(),
+		DecidedAt:      at,
+	}
+
+	normalize := func(m money.Money) (money.Money, error) {
+		converted, rate, err := e.rates.ToBase(m, at)
+		if err != nil {
+			return money.Money{}, err
+		}
+		if rate.From != rate.To && !hasRate(decision.Rates, rate) {
+			decision.Rates = append(decision.Rates, rate)
+		}
+		return converted, nil
+	}
+
+	f := facts{Applicant: a}
+	f.Age = ageAt(a, at)
+	f.AgeAtMaturity = maturityAge(a, at)
+	var err error
+	if f.MonthlyIncome, err = normalize(a.MonthlyIncome); err != nil {
+		return Decision{}, err
+	}
+	if f.LoanAmount, err = normalize(a.LoanAmount); err != nil {
+		return Decision{}, err
+	}
+	decision.MonthlyIncome = f.MonthlyIncome
+	if rule, ok := ruleset.employmentRule(a.EmploymentType); ok && rule.IncomeHaircutBps > 0 {
+		if f.MonthlyIncome, err = haircut(f.MonthlyIncome, rule.IncomeHaircutBps); err != nil {
+			return Decision{}, err
+		}
+	}
+	decision.CoBorrowerIncome = money.New(0, e.rates.Base())
+	for _, v := range a.CoBorrowerIncomes {
+		converted, err := normalize(v)
+		if err != nil {
+			return Decision{}, err
+		}
+		decision.CoBorrowerIncome = decision.CoBorrowerIncome.Add(converted)
+	}
+	f.MonthlyIncome = f.MonthlyIncome.Add(decision.CoBorrowerIncome)
+
+	f.Obligations = money.New(0, e.rates.Base())
+	for _, v := range a.ExistingObligations {
+		converted, err := normalize(v)
+		if err != nil {
+			return Decision{}, err
+		}
+		f.Obligations = f.Obligations.Add(converted)
+	}
+
+	f.Installment = money.New(0, e.rates.Base())
+	if a.TermMonths > 0 {
+		schedule, err := amortization.Calculate(f.LoanAmount, a.AnnualRateBps, a.TermMonths)
+		if err != nil {
+			return Decision{}, err
+		}
+		summary := schedule.Summary()
+		decision.Repayment = &summary
+		f.Installment = schedule.MonthlyInstallment
+	}
+
+	decision.AssessedIncome = f.MonthlyIncome
+	decision.LoanAmount = f.LoanAmount
+	decision.ExistingObligations = f.Obligations
+	decision.CreditReport = a.CreditReport
+
+	decision.Eligible = true
+	decision.Reason = MsgEligibleUnderBaseRules
+	for _, r := range baseRules {
+		check := r(ruleset, f)
+		decision.Checks = append(decision.Checks, check)
+		if !check.Passed && decision.Eligible {
+			decision.Eligible = false
+			decision.Reason = check.Reason
+		}
+	}
+
+	decision.Outcome = scorecard.OutcomeApprove
+	if !decision.Eligible {
+		decision.Outcome = scorecard.OutcomeDecline
+	}
+	if decision.Eligible && a.CreditReport != nil && a.CreditReport.Status == creditbureau.StatusUnavailable {
+		decision.Eligible = false
+		decision.Outcome = scorecard.OutcomeRefer
+		decision.Reason = MsgReferredNoCreditReport
+	}
+	if e.card != nil {
+		result := e.card.Score(scoreAttributes(f))
+		decision.Scorecard = &result
+		if decision.Eligible && result.Outcome != scorecard.OutcomeApprove {
+			decision.Eligible = false
+			decision.Outcome = result.Outcome
+			decision.Reason = MsgReferredForReview
+			if result.Outcome == scorecard.OutcomeDecline {
+				decision.Reason = fmt.Sprintf(ErrRiskGradeDeclined, result.Grade)
+			}
+		}
+	}
+
+	return decision, nil
+}
+
+// haircut counts only (10000 - bps) / 10000 of m, rounded to the minor unit.
+func haircut(m money.Money, bps int) (money.Money, error) {
+	return money.FromRat(new(big.Rat).Mul(m.Rat(), big.NewRat(int64(10000-bps), 10000)), m.Currency)
+}
+
+// ageAt is the applicant's age on the day of t. Applications stored before
+// dates of birth were captured only know the age stated on them.
+func ageAt(a Applicant, t time.Time) int {
+	if a.DateOfBirth.IsZero() {
+		return a.Age
+	}
+	return birthdate.AgeAt(a.DateOfBirth, t)
+}
+
+// maturityAge is the applicant's age when the last installment of a loan
+// taken out at the given time falls due. Without a date of birth, whole
+// years of the term are added to the stated age.
+func maturityAge(a Applicant, at time.Time) int {
+	if a.DateOfBirth.IsZero() {
+		return a.Age + a.TermMonths/12
+	}
+	return birthdate.AgeAt(a.DateOfBirth, at.AddDate(0, a.TermMonths, 0))
+}
+
+func hasRate(rates []fx.AppliedRate, rate fx.AppliedRate) bool {
+	for _, v := range rates {
+		if v == rate {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package eligibility
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+const (
+	ArmChampion   = "champion"
+	ArmChallenger = "challenger"
+)
+
+// Experiment evaluates a challenger ruleset next to the champion engine.
+// Applications are split between arms by a hash of their id, so the same
+// application always lands in the same arm.
+type Experiment struct {
+	challenger *Engine
+	percent    int
+}
+
+// NewExperiment puts percent of applications (0-100) in the challenger arm.
+// The experiment is named after the challenger's ruleset version.
+func NewExperiment(challenger *Engine, percent int) (*Experiment, error) {
+	if percent < 0 || percent > 100 {
+		return nil, fmt.Errorf("challenger percent must be between 0 and 100, got %d", percent)
+	}
+	return &Experiment{
+		challenger: challenger,
+		percent:    percent,
+	}, nil
+}
+
+func (x *Experiment) Name() string {
+	return x.challenger.ruleset.Version
+}
+
+func (x *Experiment) Challenger() *Engine {
+	return x.challenger
+}
+
+// Assign hashes the experiment name and applicationId into one of 100
+// buckets; the first percent buckets are the challenger arm. Salting with
+// the name reshuffles applications between successive experiments.
+func (x *Experiment) Assign(applicationId string) string {
+	h := fnv.New32a()
+	h.Write([]byte(x.Name() + ":" + applicationId))
+	if int(h.Sum32()%100) < x.percent {
+		return ArmChallenger
+	}
+	return ArmChampion
+}
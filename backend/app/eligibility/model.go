@@ -0,0 +1,186 @@
+package eligibility
+
+import (
+	"backend-loan-pre-approval/pkg/amortization"
+	"backend-loan-pre-approval/pkg/creditbureau"
+	"backend-loan-pre-approval/pkg/fx"
+	"backend-loan-pre-approval/pkg/money"
+	"backend-loan-pre-approval/pkg/scorecard"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Applicant is the data every rule is evaluated against. Amounts may be in
+// any currency the rate table knows; rules only ever see them in the base
+// currency. TermMonths is 0 when no term is known, e.g. for applications
+// stored before terms were captured, and CreditReport is nil when no bureau
+// was consulted. Ages are derived from DateOfBirth at decision time; Age is
+// only used for applications stored before dates of birth were captured,
+// when DateOfBirth is zero. EmploymentType is empty when the applicant did
+// not state one, and no employment rule applies. CoBorrowerIncomes are the
+// monthly incomes of co-borrowers, which count towards the applicant's.
+type Applicant struct {
+	MonthlyIncome       money.Money
+	LoanAmount          money.Money
+	LoanPurpose         string
+	DateOfBirth         time.Time
+	Age                 int
+	EmploymentType      string
+	TenureMonths        int
+	CoBorrowerIncomes   []money.Money
+	TermMonths          int
+	AnnualRateBps       int
+	ExistingObligations []money.Money
+	CreditReport        *creditbureau.Report
+}
+
+// Ruleset holds the thresholds of the base rules. Amounts are whole units of
+// the engine's base currency. A MaxAgeAtMaturity of 0 disables that rule.
+type Ruleset struct {
+	Version           string   `mapstructure:"version" json:"version"`
+	MinMonthlyIncome  int64    `mapstructure:"min_monthly_income" json:"minMonthlyIncome"`
+	MinAge            int      `mapstructure:"min_age" json:"minAge"`
+	MaxAge            int      `mapstructure:"max_age" json:"maxAge"`
+	MaxAgeAtMaturity  int      `mapstructure:"max_age_at_maturity" json:"maxAgeAtMaturity"`
+	BlockedPurposes   []string `mapstructure:"blocked_purposes" json:"blockedPurposes"`
+	MaxIncomeMultiple int64    `mapstructure:"max_income_multiple" json:"maxIncomeMultiple"`
+	MaxDsrBps         int      `mapstructure:"max_dsr_bps" json:"maxDsrBps"`
+	MinCreditScore    int      `mapstructure:"min_credit_score" json:"minCreditScore"`
+
+	// Employment holds the rules of each employment type. Keys are
+	// matched case-insensitively, since config keys are lowercased.
+	Employment map[string]EmploymentRule `mapstructure:"employment" json:"employment,omitempty"`
+}
+
+// EmploymentRule applies to applicants of one employment type.
+// IncomeHaircutBps discounts the stated income before any income rule sees
+// it, e.g. 3000 counts 70% of a self-employed applicant's income.
+type EmploymentRule struct {
+	MinTenureMonths  int `mapstructure:"min_tenure_months" json:"minTenureMonths,omitempty"`
+	IncomeHaircutBps int `mapstructure:"income_haircut_bps" json:"incomeHaircutBps,omitempty"`
+}
+
+func (rs Ruleset) employmentRule(employmentType string) (EmploymentRule, bool) {
+	for k, v := range rs.Employment {
+		if employmentType != "" && strings.EqualFold(k, employmentType) {
+			return v, true
+		}
+	}
+	return EmploymentRule{}, false
+}
+
+func DefaultRuleset() Ruleset {
+	return Ruleset{
+		Version:           "base-1",
+		MinMonthlyIncome:  10000,
+		MinAge:            20,
+		MaxAge:            60,
+		MaxAgeAtMaturity:  65,
+		BlockedPurposes:   []string{"business"},
+		MaxIncomeMultiple: 12,
+		MaxDsrBps:         6000,
+		MinCreditScore:    600,
+	}
+}
+
+// LoadRuleset reads a ruleset file using the mapstructure keys above. Keys
+// the file leaves out keep their value in base, and an employment type the
+// file lists replaces that type's base rule.
+func LoadRuleset(path string, base Ruleset) (Ruleset, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return Ruleset{}, err
+	}
+
+	rs := base
+	rs.Employment = maps.Clone(base.Employment)
+	if err := v.Unmarshal(&rs); err != nil {
+		return Ruleset{}, err
+	}
+	if rs.Version == base.Version {
+		return Ruleset{}, fmt.Errorf("ruleset %s: version must differ from %s", path, rs.Version)
+	}
+	return rs, nil
+}
+
+// RuleOverrides replaces individual Ruleset thresholds, e.g. for one loan
+// product. Nil fields keep the base value.
+type RuleOverrides struct {
+	MinMonthlyIncome  *int64 `json:"minMonthlyIncome,omitempty"`
+	MinAge            *int   `json:"minAge,omitempty"`
+	MaxAge            *int   `json:"maxAge,omitempty"`
+	MaxAgeAtMaturity  *int   `json:"maxAgeAtMaturity,omitempty"`
+	MaxIncomeMultiple *int64 `json:"maxIncomeMultiple,omitempty"`
+	MaxDsrBps         *int   `json:"maxDsrBps,omitempty"`
+	MinCreditScore    *int   `json:"minCreditScore,omitempty"`
+}
+
+func (rs Ruleset) Apply(o RuleOverrides) Ruleset {
+	if o.MinMonthlyIncome != nil {
+		rs.MinMonthlyIncome = *o.MinMonthlyIncome
+	}
+	if o.MinAge != nil {
+		rs.MinAge = *o.MinAge
+	}
+	if o.MaxAge != nil {
+		rs.MaxAge = *o.MaxAge
+	}
+	if o.MaxAgeAtMaturity != nil {
+		rs.MaxAgeAtMaturity = *o.MaxAgeAtMaturity
+	}
+	if o.MaxIncomeMultiple != nil {
+		rs.MaxIncomeMultiple = *o.MaxIncomeMultiple
+	}
+	if o.MaxDsrBps != nil {
+		rs.MaxDsrBps = *o.MaxDsrBps
+	}
+	if o.MinCreditScore != nil {
+		rs.MinCreditScore = *o.MinCreditScore
+	}
+	return rs
+}
+
+// Check is one rule's result. Value and Limit carry the computed figure and
+// threshold for ratio rules, e.g. "45.25" and "60.00" percent.
+type Check struct {
+	Rule   string `json:"rule"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Limit  string `json:"limit,omitempty"`
+}
+
+// Decision is the outcome of one evaluation, stored with the application.
+// Outcome is approve, refer or decline: a failed rule always declines,
+// otherwise an unavailable credit report refers and then the scorecard
+// grade decides when one is configured.
+// Amounts are the normalized values the rules used, in the base currency,
+// and Rates the conversions that produced them. ExistingObligations is the
+// total declared monthly debt payment, Repayment the schedule summary of
+// LoanAmount and CreditReport the bureau result without the raw response.
+// AssessedIncome is MonthlyIncome after the employment income haircut plus
+// CoBorrowerIncome, and is what the income rules were run against.
+type Decision struct {
+	Eligible            bool                   `json:"eligible"`
+	Outcome             string                 `json:"outcome"`
+	Reason              string                 `json:"reason"`
+	RulesetVersion      string                 `json:"rulesetVersion"`
+	Overrides           RuleOverrides          `json:"overrides"`
+	BaseCurrency        string                 `json:"baseCurrency"`
+	MonthlyIncome       money.Money            `json:"monthlyIncome"`
+	CoBorrowerIncome    money.Money            `json:"coBorrowerIncome"`
+	AssessedIncome      money.Money            `json:"assessedIncome"`
+	LoanAmount          money.Money            `json:"loanAmount"`
+	ExistingObligations money.Money            `json:"existingObligations"`
+	Rates               []fx.AppliedRate       `json:"rates,omitempty"`
+	Repayment           *amortization.Schedule `json:"repayment,omitempty"`
+	CreditReport        *creditbureau.Report   `json:"creditReport,omitempty"`
+	Checks              []Check                `json:"checks"`
+	Scorecard           *scorecard.Result      `json:"scorecard,omitempty"`
+	DecidedAt           time.Time              `json:"decidedAt"`
+}
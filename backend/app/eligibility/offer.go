@@ -0,0 +1,160 @@
+package eligibility
+
+import (
+	"backend-loan-pre-approval/pkg/amortization"
+	"backend-loan-pre-approval/pkg/money"
+	"backend-loan-pre-approval/pkg/scorecard"
+	"time"
+)
+
+const (
+	OfferReducedAmount = "reducedAmount"
+	OfferExtendedTerm  = "extendedTerm"
+)
+
+// amountRules are the only rules a counter-offer can fix by changing the
+// loan amount or term.
+var amountRules = map[string]bool{
+	RuleIncomeMultiple:   true,
+	RuleDebtServiceRatio: true,
+}
+
+// Offer is a counter-offer for a declined application. Each option changes
+// one of amount or term so that every rule passes.
+type Offer struct {
+	Options   []OfferOption `json:"options"`
+	ExpiresAt time.Time     `json:"expiresAt"`
+}
+
+type OfferOption struct {
+	Type               string      `json:"type"`
+	LoanAmount         money.Money `json:"loanAmount"`
+	TermMonths         int         `json:"termMonths"`
+	MonthlyInstallment money.Money `json:"monthlyInstallment"`
+}
+
+// Option returns the option of the given type, if offered.
+func (o Offer) Option(optionType string) (OfferOption, bool) {
+	for _, v := range o.Options {
+		if v.Type == optionType {
+			return v, true
+		}
+	}
+	return OfferOption{}, false
+}
+
+// CounterOffer looks for the largest whole-unit loan amount at the requested
+// term, and the shortest term up to maxTermMonths at the requested amount, at
+// which a declined applicant passes every rule. It returns nil for referred
+// applications, when decision failed a rule that neither can fix, or when no
+// option exists.
+func (e *Engine) CounterOffer(a Applicant, overrides RuleOverrides, at time.Time, decision Decision, maxTermMonths int) (*Offer, error) {
+	if decision.Eligible || decision.Outcome == scorecard.OutcomeRefer || a.TermMonths == 0 {
+		return nil, nil
+	}
+	for _, v := range decision.Checks {
+		if !v.Passed && !amountRules[v.Rule] {
+			return nil, nil
+		}
+	}
+
+	passes := func(candidate Applicant) (bool, error) {
+		d, err := e.Evaluate(candidate, overrides, at)
+		if err != nil {
+			return false, err
+		}
+		return d.Eligible, nil
+	}
+
+	offer := &Offer{}
+
+	// Both rules get easier as the amount falls, so binary search the
+	// largest passing amount in whole units below the requested one.
+	unit := money.FromMajor(1, a.LoanAmount.Currency).Amount
+	lo, hi := int64(0), a.LoanAmount.Amount/unit-1
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		candidate := a
+		candidate.LoanAmount = money.New(mid*unit, a.LoanAmount.Currency)
+		ok, err := passes(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	if lo > 0 {
+		amount := money.New(lo*unit, a.LoanAmount.Currency)
+		installment, err := amortization.Installment(amount, a.AnnualRateBps, a.TermMonths)
+		if err != nil {
+			return nil, err
+		}
+		offer.Options = append(offer.Options, OfferOption{
+			Type:               OfferReducedAmount,
+			LoanAmount:         amount,
+			TermMonths:         a.TermMonths,
+			MonthlyInstallment: installment,
+		})
+	}
+
+	// The applicant ages as the term grows, so terms ending after the
+	// maximum age at maturity are never offered.
+	maxTermMonths = max(a.TermMonths, e.LongestTerm(a, overrides, at, maxTermMonths))
+
+	// Below that, only the debt service ratio depends on the term and it
+	// falls as the term grows, so binary search the shortest passing term.
+	longest := a
+	longest.TermMonths = maxTermMonths
+	if ok, err := passes(longest); err != nil {
+		return nil, err
+	} else if ok && maxTermMonths > a.TermMonths {
+		lo, hi := a.TermMonths+1, maxTermMonths
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			candidate := a
+			candidate.TermMonths = mid
+			ok, err := passes(candidate)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+		installment, err := amortization.Installment(a.LoanAmount, a.AnnualRateBps, lo)
+		if err != nil {
+			return nil, err
+		}
+		offer.Options = append(offer.Options, OfferOption{
+			Type:               OfferExtendedTerm,
+			LoanAmount:         a.LoanAmount,
+			TermMonths:         lo,
+			MonthlyInstallment: installment,
+		})
+	}
+
+	if len(offer.Options) == 0 {
+		return nil, nil
+	}
+	return offer, nil
+}
+
+// LongestTerm shortens maxTermMonths until a loan taken out at the given time
+// matures before the applicant passes the maximum age at maturity. It returns
+// 0 when no term does.
+func (e *Engine) LongestTerm(a Applicant, overrides RuleOverrides, at time.Time, maxTermMonths int) int {
+	ruleset := e.ruleset.Apply(overrides)
+	for maxTermMonths > 0 && ruleset.MaxAgeAtMaturity > 0 {
+		a.TermMonths = maxTermMonths
+		if maturityAge(a, at) <= ruleset.MaxAgeAtMaturity {
+			break
+		}
+		maxTermMonths--
+	}
+	return maxTermMonths
+}
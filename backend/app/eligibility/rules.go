@@ -0,0 +1,191 @@
+package eligibility
+
+import (
+	"backend-loan-pre-approval/pkg/creditbureau"
+	"backend-loan-pre-approval/pkg/money"
+	"backend-loan-pre-approval/pkg/scorecard"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// facts is the applicant with every amount in the base currency, the
+// monthly income after any employment haircut and Age as of decision time,
+// plus the values derived from it that rules share.
+type facts struct {
+	Applicant
+	Obligations   money.Money
+	Installment   money.Money
+	AgeAtMaturity int
+}
+
+type rule func(rs Ruleset, f facts) Check
+
+// baseRules run in order; the first failing check gives the decision reason.
+var baseRules = []rule{
+	minMonthlyIncomeRule,
+	ageRangeRule,
+	ageAtMaturityRule,
+	employmentTenureRule,
+	purposeRule,
+	incomeMultipleRule,
+	debtServiceRatioRule,
+	creditScoreRule,
+}
+
+func minMonthlyIncomeRule(rs Ruleset, f facts) Check {
+	check := Check{Rule: RuleMinMonthlyIncome, Passed: true}
+	if f.MonthlyIncome.LessThan(money.FromMajor(rs.MinMonthlyIncome, f.MonthlyIncome.Currency)) {
+		check.Passed = false
+		check.Reason = ErrMonthlyIncomeInsufficient
+	}
+	return check
+}
+
+func ageRangeRule(rs Ruleset, f facts) Check {
+	check := Check{Rule: RuleAgeRange, Passed: true, Value: strconv.Itoa(f.Age)}
+	if f.Age < rs.MinAge || f.Age > rs.MaxAge {
+		check.Passed = false
+		check.Reason = fmt.Sprintf(ErrAgeNotInRange, rs.MinAge, rs.MaxAge)
+	}
+	return check
+}
+
+// ageAtMaturityRule compares the applicant's age on the last installment
+// with the ruleset ceiling. A ceiling of 0 disables it.
+func ageAtMaturityRule(rs Ruleset, f facts) Check {
+	check := Check{Rule: RuleAgeAtMaturity, Passed: true}
+	if rs.MaxAgeAtMaturity <= 0 {
+		return check
+	}
+
+	check.Value = strconv.Itoa(f.AgeAtMaturity)
+	check.Limit = strconv.Itoa(rs.MaxAgeAtMaturity)
+	if f.AgeAtMaturity > rs.MaxAgeAtMaturity {
+		check.Passed = false
+		check.Reason = fmt.Sprintf(ErrAgeAtMaturityExceeded, rs.MaxAgeAtMaturity)
+	}
+	return check
+}
+
+// employmentTenureRule applies the minimum tenure of the applicant's
+// employment type. Types without a rule, and applicants who gave no type,
+// pass.
+func employmentTenureRule(rs Ruleset, f facts) Check {
+	check := Check{Rule: RuleEmploymentTenure, Passed: true}
+	rule, ok := rs.employmentRule(f.EmploymentType)
+	if !ok || rule.MinTenureMonths <= 0 {
+		return check
+	}
+
+	check.Value = strconv.Itoa(f.TenureMonths)
+	check.Limit = strconv.Itoa(rule.MinTenureMonths)
+	if f.TenureMonths < rule.MinTenureMonths {
+		check.Passed = false
+		check.Reason = fmt.Sprintf(ErrTenureTooShort, rule.MinTenureMonths, f.EmploymentType)
+	}
+	return check
+}
+
+func purposeRule(rs Ruleset, f facts) Check {
+	check := Check{Rule: RulePurpose, Passed: true}
+	for _, v := range rs.BlockedPurposes {
+		if v != "" && f.LoanPurpose == v {
+			check.Passed = false
+			check.Reason = fmt.Sprintf(ErrLoansNotSupported, strings.ToUpper(v[:1])+v[1:])
+		}
+	}
+	return check
+}
+
+func incomeMultipleRule(rs Ruleset, f facts) Check {
+	check := Check{Rule: RuleIncomeMultiple, Passed: true}
+	if f.LoanAmount.GreaterThan(f.MonthlyIncome.Mul(rs.MaxIncomeMultiple)) {
+		check.Passed = false
+		check.Reason = fmt.Sprintf(ErrLoanAmountExceedsCap, rs.MaxIncomeMultiple)
+	}
+	return check
+}
+
+// debtServiceRatioRule compares (existing obligations + new installment) /
+// monthly income with the ruleset ceiling. A ceiling of 0 disables it.
+func debtServiceRatioRule(rs Ruleset, f facts) Check {
+	check := Check{Rule: RuleDebtServiceRatio, Passed: true}
+	if rs.MaxDsrBps <= 0 || f.MonthlyIncome.Amount <= 0 {
+		return check
+	}
+
+	ratio := big.NewRat(f.Obligations.Amount+f.Installment.Amount, f.MonthlyIncome.Amount)
+	limit := big.NewRat(int64(rs.MaxDsrBps), 10000)
+
+	check.Value = percent(ratio)
+	check.Limit = percent(limit)
+	if ratio.Cmp(limit) > 0 {
+		check.Passed = false
+		check.Reason = fmt.Sprintf(ErrDebtServiceRatioExceeded, check.Value, check.Limit)
+	}
+	return check
+}
+
+// creditScoreRule passes when no bureau was consulted and on a no-hit, so
+// applicants without a credit history are judged on the other rules alone.
+// It also passes when the bureau could not be reached; Evaluate refers
+// those applicants instead of deciding them.
+func creditScoreRule(rs Ruleset, f facts) Check {
+	check := Check{Rule: RuleCreditScore, Passed: true}
+	if f.CreditReport == nil {
+		return check
+	}
+
+	switch f.CreditReport.Status {
+	case creditbureau.StatusHit:
+		check.Value = strconv.Itoa(f.CreditReport.Score)
+		check.Limit = strconv.Itoa(rs.MinCreditScore)
+		if f.CreditReport.Score < rs.MinCreditScore {
+			check.Passed = false
+			check.Reason = fmt.Sprintf(ErrCreditScoreTooLow, rs.MinCreditScore)
+		}
+	case creditbureau.StatusNoHit:
+		check.Value = creditbureau.StatusNoHit
+	default:
+		check.Value = f.CreditReport.Status
+	}
+	return check
+}
+
+// scoreAttributes gives the scorecard the same normalized facts the rules
+// saw. Ratios without a term or credit score without a hit are left missing.
+func scoreAttributes(f facts) scorecard.Attributes {
+	income, _ := f.MonthlyIncome.Rat().Float64()
+	attrs := scorecard.Attributes{
+		Numeric: map[string]float64{
+			ScoreMonthlyIncome: income,
+			ScoreAge:           float64(f.Age),
+		},
+		Categorical: map[string]string{
+			ScorePurpose: f.LoanPurpose,
+		},
+	}
+	if f.EmploymentType != "" {
+		attrs.Categorical[ScoreEmploymentType] = f.EmploymentType
+	}
+
+	if f.MonthlyIncome.Amount > 0 {
+		lti, _ := big.NewRat(f.LoanAmount.Amount, f.MonthlyIncome.Amount).Float64()
+		attrs.Numeric[ScoreLoanToIncome] = lti
+		if f.TermMonths > 0 {
+			dsr, _ := big.NewRat((f.Obligations.Amount+f.Installment.Amount)*100, f.MonthlyIncome.Amount).Float64()
+			attrs.Numeric[ScoreDebtServiceRatio] = dsr
+		}
+	}
+	if f.CreditReport != nil && f.CreditReport.Status == creditbureau.StatusHit {
+		attrs.Numeric[ScoreCreditScore] = float64(f.CreditReport.Score)
+	}
+	return attrs
+}
+
+// percent renders a ratio as a percentage with two decimals, e.g. "45.25".
+func percent(r *big.Rat) string {
+	return new(big.Rat).Mul(r, big.NewRat(100, 1)).FloatString(2)
+}
@@ -0,0 +1,28 @@
+package experiments
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetMetrics(c *gin.Context) {
+
+	experiment := c.Param("experiment")
+
+	res, err := h.service.GetMetrics(c.Request.Context(), experiment)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
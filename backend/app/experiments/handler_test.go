@@ -0,0 +1,41 @@
+package experiments
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+func Test_GetMetrics(t *testing.T) {
+	mockRepo := NewMockRepo()
+	h := NewHandler(NewService(mockRepo))
+
+	mockRepo.On("GetArmMetrics", mock.Anything, "challenger-1").Return([]ArmMetricsEntity{
+		{Arm: "challenger", Applications: 8, ChampionApproved: 4, ChallengerApproved: 3, Agreed: 7},
+		{Arm: "champion", Applications: 32, ChampionApproved: 16, ChallengerApproved: 12, Agreed: 28},
+	}, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/experiments/:experiment/metrics", h.GetMetrics)
+
+	req := httptest.NewRequest(http.MethodGet, "http://0.0.0.0/api/v1/experiments/challenger-1/metrics", nil)
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response MetricsResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "challenger-1", response.Experiment)
+	assert.Equal(t, "37.50", response.Arms[0].ChallengerApprovalRate)
+	assert.Equal(t, "87.50", response.Arms[1].AgreementRate)
+}
@@ -0,0 +1,35 @@
+package experiments
+
+// ======== sample metrics response ======== //
+// {
+// 	"experiment": "challenger-2026-11",
+// 	"arms": [
+// 		{
+// 			"arm": "challenger",
+// 			"applications": 120,
+// 			"championApproved": 54,
+// 			"challengerApproved": 47,
+// 			"championApprovalRate": "45.00",
+// 			"challengerApprovalRate": "39.17",
+// 			"agreementRate": "94.17"
+// 		},
+// 		...
+// 	]
+// }
+
+type MetricsResponse struct {
+	Experiment string       `json:"experiment"`
+	Arms       []ArmMetrics `json:"arms"`
+}
+
+// ArmMetrics compares the champion (shown) and challenger (recorded)
+// decisions within one arm. Rates are percentages with two decimals.
+type ArmMetrics struct {
+	Arm                    string `json:"arm"`
+	Applications           int    `json:"applications"`
+	ChampionApproved       int    `json:"championApproved"`
+	ChallengerApproved     int    `json:"challengerApproved"`
+	ChampionApprovalRate   string `json:"championApprovalRate"`
+	ChallengerApprovalRate string `json:"challengerApprovalRate"`
+	AgreementRate          string `json:"agreementRate"`
+}
@@ -0,0 +1,34 @@
+package experiments
+
+import (
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetArmMetrics(ctx context.Context, experiment string) ([]ArmMetricsEntity, error)
+}
+
+type RepositoryImpl struct {
+	queries *store.Queries
+}
+
+func NewRepository(db *sqlx.DB) Repository {
+	return &RepositoryImpl{
+		queries: store.New(db),
+	}
+}
+
+func (r *RepositoryImpl) GetArmMetrics(ctx context.Context, experiment string) ([]ArmMetricsEntity, error) {
+
+	metrics, err := r.queries.ListExperimentArmMetrics(ctx, experiment)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,5 @@
+package experiments
+
+import "backend-loan-pre-approval/pkg/store"
+
+type ArmMetricsEntity = store.ExperimentArmMetrics
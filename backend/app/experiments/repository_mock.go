@@ -0,0 +1,22 @@
+package experiments
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepo struct {
+	mock.Mock
+}
+
+// Helper function to create a new repository with mocks
+func NewMockRepo() *MockRepo {
+
+	return &MockRepo{}
+}
+
+func (m *MockRepo) GetArmMetrics(ctx context.Context, experiment string) ([]ArmMetricsEntity, error) {
+	args := m.Called(ctx, experiment)
+	return args.Get(0).([]ArmMetricsEntity), args.Error(1)
+}
@@ -0,0 +1,51 @@
+package experiments
+
+import (
+	"context"
+	"math/big"
+)
+
+type Service interface {
+	GetMetrics(ctx context.Context, experiment string) (MetricsResponse, error)
+}
+
+type ServiceImpl struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &ServiceImpl{
+		repository: repository,
+	}
+}
+
+func (s *ServiceImpl) GetMetrics(ctx context.Context, experiment string) (MetricsResponse, error) {
+
+	result, err := s.repository.GetArmMetrics(ctx, experiment)
+	if err != nil {
+		return MetricsResponse{}, err
+	}
+
+	res := MetricsResponse{Experiment: experiment, Arms: []ArmMetrics{}}
+	for _, v := range result {
+		res.Arms = append(res.Arms, ArmMetrics{
+			Arm:                    v.Arm,
+			Applications:           v.Applications,
+			ChampionApproved:       v.ChampionApproved,
+			ChallengerApproved:     v.ChallengerApproved,
+			ChampionApprovalRate:   rate(v.ChampionApproved, v.Applications),
+			ChallengerApprovalRate: rate(v.ChallengerApproved, v.Applications),
+			AgreementRate:          rate(v.Agreed, v.Applications),
+		})
+	}
+
+	return res, nil
+}
+
+// rate renders n/total as a percentage with two decimals, e.g. "45.25".
+func rate(n int, total int) string {
+	if total == 0 {
+		return "0.00"
+	}
+	return new(big.Rat).SetFrac64(int64(n)*100, int64(total)).FloatString(2)
+}
@@ -0,0 +1,20 @@
+package experiments
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockService struct {
+	mock.Mock
+}
+
+func NewMockService() *MockService {
+	return &MockService{}
+}
+
+func (m *MockService) GetMetrics(ctx context.Context, experiment string) (MetricsResponse, error) {
+	args := m.Called(ctx, experiment)
+	return args.Get(0).(MetricsResponse), args.Error(1)
+}
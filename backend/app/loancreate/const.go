@@ -1,15 +1,68 @@
 package loancreate
 
+import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/pkg/store"
+	"errors"
+	"time"
+)
+
+const (
+	MsgReasonSuccess     = eligibility.MsgEligibleUnderBaseRules
+	MsgInvalidBody       = "Invalid request body"
+	MsgDraftNotCreated   = "Draft could not be created"
+	MsgDraftNotUpdated   = "Draft could not be updated"
+	MsgDraftNotSubmitted = "Draft could not be submitted"
+)
+
+// Validation codes distinguish failures the applicant can act on from a
+// malformed request.
 const (
-	MsgReasonSuccess = "Eligible under base rules"
-	MsgInvalidBody   = "Invalid request body"
+	CodeDisposableEmail = "disposableEmail"
 )
 
 var (
-	ErrMonthlyIncomeInsufficient = "Monthly income is insufficient"
-	ErrAgeNotInRange             = "Age not in range (must be between 20-60)"
-	ErrBusinessLoansNotSupported = "Business loans not supported"
-	ErrLoanAmountExceedsCap      = "Loan amount cannot exceed 12 months of income"
+	ErrApplicationNotFound  = errors.New("Loan application not found")
+	ErrNotDraft             = errors.New("Loan application is not a draft")
+	ErrPreconditionRequired = errors.New("If-Match header with the draft ETag is required")
+	ErrVersionMismatch      = errors.New("Draft was modified since it was read")
+)
+
+// Amount limits, in whole units of the base currency, apply to the request
+// after conversion.
+const (
+	MinMonthlyIncome = 5000
+	MaxMonthlyIncome = 5000000
+	MinLoanAmount    = 1000
+	MaxLoanAmount    = 5000000
+)
+
+// Name limits are in characters, not bytes.
+const (
+	MinFullNameLength = 2
+	MaxFullNameLength = 255
+	MaxNamePartLength = 100
 )
 
-var PurposeList = []string{"home", "car", "education", "personal", "business"}
+var ObligationTypes = []string{"loan", "creditCard"}
+
+var EmploymentTypes = []string{"salaried", "selfEmployed", "government", "retired"}
+
+// EmployerRequiredTypes are the employment types that must name an employer.
+var EmployerRequiredTypes = []string{"salaried", "government"}
+
+var IncomeFrequencies = []string{"monthly", "biweekly", "weekly", "daily", "irregular"}
+
+const MaxEmployerNameLength = 255
+
+const (
+	RoleCoBorrower = store.RoleCoBorrower
+	RoleGuarantor  = store.RoleGuarantor
+)
+
+var CoApplicantRoles = []string{RoleCoBorrower, RoleGuarantor}
+
+const MaxCoApplicants = 4
+
+// OfferValidity is how long a counter-offer can be accepted.
+const OfferValidity = 7 * 24 * time.Hour
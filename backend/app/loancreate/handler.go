@@ -1,11 +1,19 @@
 package loancreate
 
 import (
+	"backend-loan-pre-approval/app/notifications"
+	"backend-loan-pre-approval/pkg/birthdate"
+	"backend-loan-pre-approval/pkg/emailaddr"
+	"backend-loan-pre-approval/pkg/nationalid"
+	"backend-loan-pre-approval/pkg/personname"
+	"backend-loan-pre-approval/pkg/phone"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
-	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -31,37 +39,202 @@ func (h *Handler) LoansCreate(c *gin.Context) {
 		})
 		return
 	}
+	req = normalizeRequest(req)
 
-	if err := h.validateRequest(req); err != nil {
+	if c.Query("draft") == "true" {
+		h.createDraft(c, req)
+		return
+	}
+
+	if err := validateRequest(req); err != nil {
+		log.Println("err: ", err)
+		c.JSON(http.StatusBadRequest, HttpBadResponse{
+			Message: MsgInvalidBody,
+			Reason:  err.Error(),
+			Code:    errorCode(err),
+		})
+		return
+	}
+
+	// Ineligible applications are still a successful response with
+	// eligible: false; err is only set when nothing was stored.
+	res, err := h.services.CreateLoanApplication(c.Request.Context(), req)
+	if err != nil {
+		log.Println("err: ", err)
+		var validationErr ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, HttpBadResponse{
+				Message: MsgInvalidBody,
+				Reason:  err.Error(),
+				Code:    errorCode(err),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// EligibilitySimulate validates and evaluates the request exactly like
+// LoansCreate but stores nothing and assigns no application id.
+func (h *Handler) EligibilitySimulate(c *gin.Context) {
+
+	var req HttpRequest
+	if err := c.Bind(&req); err != nil {
+		log.Println("err: ", err)
+		c.JSON(http.StatusInternalServerError, HttpBadResponse{
+			Message: MsgInvalidBody,
+			Reason:  err.Error(),
+		})
+		return
+	}
+	req = normalizeRequest(req)
+
+	if err := validateRequest(req); err != nil {
+		log.Println("err: ", err)
+		c.JSON(http.StatusBadRequest, HttpBadResponse{
+			Message: MsgInvalidBody,
+			Reason:  err.Error(),
+			Code:    errorCode(err),
+		})
+		return
+	}
+
+	res, err := h.services.SimulateLoanApplication(c.Request.Context(), req)
+	if err != nil {
+		log.Println("err: ", err)
+		var validationErr ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, HttpBadResponse{
+				Message: MsgInvalidBody,
+				Reason:  err.Error(),
+				Code:    errorCode(err),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// createDraft stores req as a draft. Only the values that are present are
+// checked; missing fields are reported when the draft is submitted.
+func (h *Handler) createDraft(c *gin.Context, req HttpRequest) {
+
+	if err := checkValueCondition(req); err != nil {
 		log.Println("err: ", err)
 		c.JSON(http.StatusBadRequest, HttpBadResponse{
 			Message: MsgInvalidBody,
 			Reason:  err.Error(),
+			Code:    errorCode(err),
 		})
 		return
 	}
 
-	applicationId, timestamp, err := h.services.CreateLoanApplication(c.Request.Context(), req)
+	res, err := h.services.CreateDraft(c.Request.Context(), req)
+	if err != nil {
+		log.Println("err: ", err)
+		draftError(c, MsgDraftNotCreated, err)
+		return
+	}
+
+	c.Header("ETag", etag(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
+// LoansUpdateDraft merges the fields present in the body into a draft. The
+// If-Match header must carry the draft's current ETag.
+func (h *Handler) LoansUpdateDraft(c *gin.Context) {
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		draftError(c, MsgDraftNotUpdated, err)
+		return
+	}
+
+	patch, err := c.GetRawData()
 	if err != nil {
-		// Eligibility errors: return HTTP 200 with eligible: false
-		c.JSON(http.StatusOK, HttpResponse{
-			ApplicationId: applicationId,
-			Eligible:      false,
-			Reason:        err.Error(),
-			Timestamp:     timestamp,
+		log.Println("err: ", err)
+		c.JSON(http.StatusInternalServerError, HttpBadResponse{
+			Message: MsgInvalidBody,
+			Reason:  err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, HttpResponse{
-		ApplicationId: applicationId,
-		Eligible:      true,
-		Reason:        MsgReasonSuccess,
-		Timestamp:     timestamp,
-	})
+	res, err := h.services.UpdateDraft(c.Request.Context(), c.Param("applicationId"), version, patch)
+	if err != nil {
+		log.Println("err: ", err)
+		draftError(c, MsgDraftNotUpdated, err)
+		return
+	}
+
+	c.Header("ETag", etag(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
+// LoansSubmitDraft evaluates a draft and stores the decision, answering like
+// LoansCreate. The If-Match header must carry the draft's current ETag.
+func (h *Handler) LoansSubmitDraft(c *gin.Context) {
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		draftError(c, MsgDraftNotSubmitted, err)
+		return
+	}
+
+	res, err := h.services.SubmitDraft(c.Request.Context(), c.Param("applicationId"), version)
+	if err != nil {
+		log.Println("err: ", err)
+		draftError(c, MsgDraftNotSubmitted, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func draftError(c *gin.Context, message string, err error) {
+	var validationErr ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, HttpBadResponse{Message: message, Reason: err.Error(), Code: validationErr.Code})
+	case errors.Is(err, ErrApplicationNotFound):
+		c.JSON(http.StatusNotFound, HttpBadResponse{Message: message, Reason: err.Error()})
+	case errors.Is(err, ErrNotDraft):
+		c.JSON(http.StatusConflict, HttpBadResponse{Message: message, Reason: err.Error()})
+	case errors.Is(err, ErrVersionMismatch):
+		c.JSON(http.StatusPreconditionFailed, HttpBadResponse{Message: message, Reason: err.Error()})
+	case errors.Is(err, ErrPreconditionRequired):
+		c.JSON(http.StatusPreconditionRequired, HttpBadResponse{Message: message, Reason: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	}
+}
+
+// etag is the entity tag of a draft at version.
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatchVersion reads the draft version from the If-Match header. A weak
+// tag is accepted; anything that is not a version can never match.
+func ifMatchVersion(c *gin.Context) (int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return 0, ErrPreconditionRequired
+	}
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(header, "W/"), `"`))
+	if err != nil {
+		return 0, ErrVersionMismatch
+	}
+	return version, nil
 }
 
-func (h *Handler) validateRequest(req HttpRequest) error {
+func validateRequest(req HttpRequest) error {
 
 	missing := checkMissingFields(req)
 	if len(missing) > 0 {
@@ -76,17 +249,13 @@ func (h *Handler) validateRequest(req HttpRequest) error {
 }
 
 func checkMissingFields(req HttpRequest) []string {
-	missing := []string{}
+	missing := checkMissingPersonalDetails(req.PersonalDetails, "")
 
-	if req.FullName == "" {
-		missing = append(missing, "fullName")
-	}
-
-	if req.MonthlyIncome == 0 {
+	if req.MonthlyIncome.IsZero() {
 		missing = append(missing, "monthlyIncome")
 	}
 
-	if req.LoanAmount == 0 {
+	if req.LoanAmount.IsZero() {
 		missing = append(missing, "loanAmount")
 	}
 
@@ -94,67 +263,252 @@ func checkMissingFields(req HttpRequest) []string {
 		missing = append(missing, "loanPurpose")
 	}
 
-	if req.Age == 0 {
-		missing = append(missing, "age")
+	if e := req.Employment; e != nil {
+		if e.Type == "" {
+			missing = append(missing, "employment.type")
+		}
+		if e.EmployerName == "" && isOneOf(e.Type, EmployerRequiredTypes) {
+			missing = append(missing, "employment.employerName")
+		}
+	}
+
+	for i, v := range req.CoApplicants {
+		prefix := fmt.Sprintf("coApplicants[%d].", i)
+		if v.Role == "" {
+			missing = append(missing, prefix+"role")
+		}
+		missing = append(missing, checkMissingPersonalDetails(v.PersonalDetails, prefix)...)
+		if v.Role == RoleCoBorrower && v.MonthlyIncome.IsZero() {
+			missing = append(missing, prefix+"monthlyIncome")
+		}
+	}
+
+	return missing
+}
+
+// checkMissingPersonalDetails names the missing fields with prefix, e.g.
+// "coApplicants[0].".
+func checkMissingPersonalDetails(p PersonalDetails, prefix string) []string {
+	missing := []string{}
+
+	if p.FullName == "" {
+		missing = append(missing, prefix+"fullName")
+	}
+
+	if p.GivenNameTh != "" && p.FamilyNameTh == "" {
+		missing = append(missing, prefix+"familyNameTh")
+	}
+	if p.FamilyNameTh != "" && p.GivenNameTh == "" {
+		missing = append(missing, prefix+"givenNameTh")
+	}
+	if p.GivenNameEn != "" && p.FamilyNameEn == "" {
+		missing = append(missing, prefix+"familyNameEn")
+	}
+	if p.FamilyNameEn != "" && p.GivenNameEn == "" {
+		missing = append(missing, prefix+"givenNameEn")
+	}
+
+	if p.DateOfBirth == "" {
+		missing = append(missing, prefix+"dateOfBirth")
 	}
 
-	if req.PhoneNumber == "" {
-		missing = append(missing, "phoneNumber")
+	if p.PhoneNumber == "" {
+		missing = append(missing, prefix+"phoneNumber")
 	}
 
-	if req.Email == "" {
-		missing = append(missing, "email")
+	if p.Email == "" {
+		missing = append(missing, prefix+"email")
 	}
 
 	return missing
 }
 
+// checkValueCondition validates the fields that are set. Empty text fields
+// are left to checkMissingFields so drafts can be saved incomplete.
 func checkValueCondition(req HttpRequest) error {
 
-	if len(req.FullName) < 2 || len(req.FullName) > 255 {
-		return errors.New("Full name must be between 2 and 255 characters")
+	if err := checkPersonalDetails(req.PersonalDetails); err != nil {
+		return err
+	}
+	if req.MonthlyIncome.Amount < 0 || req.LoanAmount.Amount < 0 {
+		return errors.New("Amounts must not be negative")
+	}
+	if req.TermMonths < 0 {
+		return errors.New("Loan term must not be negative")
+	}
+	if e := req.Employment; e != nil {
+		if e.Type != "" && !isOneOf(e.Type, EmploymentTypes) {
+			return errors.New("Employment type must be one of: " + strings.Join(EmploymentTypes, ", "))
+		}
+		if personname.Length(e.EmployerName) > MaxEmployerNameLength {
+			return errors.New("Employer name must be at most 255 characters")
+		}
+		if e.TenureMonths < 0 {
+			return errors.New("Employment tenure must not be negative")
+		}
+		if e.IncomeFrequency != "" && !isOneOf(e.IncomeFrequency, IncomeFrequencies) {
+			return errors.New("Income frequency must be one of: " + strings.Join(IncomeFrequencies, ", "))
+		}
+	}
+	if req.Locale != "" && !isOneOf(req.Locale, notifications.Locales) {
+		return errors.New("Locale must be one of: " + strings.Join(notifications.Locales, ", "))
+	}
+	if len(req.CoApplicants) > MaxCoApplicants {
+		return fmt.Errorf("An application can have at most %d co-applicants", MaxCoApplicants)
+	}
+	for i, v := range req.CoApplicants {
+		if err := checkCoApplicant(req, v); err != nil {
+			return ValidationError{Code: errorCode(err), Reason: fmt.Sprintf("coApplicants[%d]: %s", i, err.Error())}
+		}
+	}
+	for _, v := range req.ExistingObligations {
+		if !isOneOf(v.Type, ObligationTypes) {
+			return errors.New("Obligation type must be one of: " + strings.Join(ObligationTypes, ", "))
+		}
+		if v.MonthlyPayment.Amount <= 0 {
+			return errors.New("Obligation monthly payment must be more than 0")
+		}
+	}
+	return nil
+}
+
+// checkPersonalDetails checks the fields of p that are set. Names are
+// measured in characters after NFC normalization and each optional name
+// pair must be written in its own script. Local phone numbers are read in
+// phone.default_region.
+func checkPersonalDetails(p PersonalDetails) error {
+
+	if p.FullName != "" {
+		if n := personname.Length(p.FullName); n < MinFullNameLength || n > MaxFullNameLength {
+			return errors.New("Full name must be between 2 and 255 characters")
+		}
+		if _, err := personname.DetectScript(p.FullName); err != nil {
+			return errors.New("Full name must be written in Thai or Latin letters")
+		}
+	}
+	if !isNameInScript(p.GivenNameTh, personname.Thai) || !isNameInScript(p.FamilyNameTh, personname.Thai) {
+		return errors.New("Thai given and family names must be written in Thai script, up to 100 characters")
+	}
+	if !isNameInScript(p.GivenNameEn, personname.Latin) || !isNameInScript(p.FamilyNameEn, personname.Latin) {
+		return errors.New("English given and family names must be written in Latin script, up to 100 characters")
+	}
+	if p.NationalId != "" && nationalid.Validate(p.NationalId) != nil {
+		return errors.New("National ID must be 13 digits with a valid checksum")
+	}
+	if p.DateOfBirth != "" {
+		if dob, err := birthdate.Parse(p.DateOfBirth); err != nil || dob.After(birthdate.Today(time.Now())) {
+			return errors.New("Date of birth must be a past date in YYYY-MM-DD format")
+		}
 	}
-	if req.MonthlyIncome < 5000 || req.MonthlyIncome > 5000000 {
-		return errors.New("Monthly income must be between 5,000 and 5,000,000")
+	if p.PhoneNumber != "" {
+		if _, err := phone.Parse(p.PhoneNumber); err != nil {
+			return errors.New("Phone number must be a valid mobile or landline number, e.g. 0851234567 or +66851234567")
+		}
 	}
-	if req.LoanAmount < 1000 || req.LoanAmount > 5000000 {
-		return errors.New("Loan amount must be between 1,000 and 5,000,000")
+	if p.Email != "" {
+		if _, err := emailaddr.Parse(p.Email); errors.Is(err, emailaddr.ErrDisposableDomain) {
+			return ValidationError{Code: CodeDisposableEmail, Reason: "Email domain belongs to a disposable email provider"}
+		} else if err != nil {
+			return errors.New("Email must be a valid email address")
+		}
 	}
-	if !isPurposeValid(req.LoanPurpose) {
-		return errors.New("Loan purpose must be one of: home, car, education, wedding, other")
+	return nil
+}
+
+// checkCoApplicant also rejects a co-applicant who is the applicant, as far
+// as their national ID tells.
+func checkCoApplicant(req HttpRequest, c CoApplicant) error {
+
+	if c.Role != "" && !isOneOf(c.Role, CoApplicantRoles) {
+		return errors.New("Role must be one of: " + strings.Join(CoApplicantRoles, ", "))
 	}
-	if req.Age < 0 {
-		return errors.New("Age must be a number more than 0")
+	if err := checkPersonalDetails(c.PersonalDetails); err != nil {
+		return err
 	}
-	if !isNumericPhone(req.PhoneNumber) || len(req.PhoneNumber) != 10 {
-		return errors.New("Phone number must be 10 digits and numeric")
+	if c.MonthlyIncome.Amount < 0 {
+		return errors.New("Amounts must not be negative")
 	}
-	if !isValidEmail(req.Email) {
-		return errors.New("Email must be a valid email address")
+	if c.NationalId != "" && c.NationalId == req.NationalId {
+		return errors.New("Co-applicant must not be the applicant")
 	}
 	return nil
 }
 
-func isNumericPhone(phone string) bool {
-	for _, c := range phone {
-		if c < '0' || c > '9' {
-			return false
+// normalizeRequest puts names and contact details in the form they are
+// validated and stored in, and fills in fullName from a complete name pair
+// when it is left empty. An email address that does not parse is left as
+// is for checkValueCondition to reject.
+func normalizeRequest(req HttpRequest) HttpRequest {
+	req.PersonalDetails = normalizePersonalDetails(req.PersonalDetails)
+	req.Locale = strings.ToLower(strings.TrimSpace(req.Locale))
+	if req.Employment != nil {
+		employment := *req.Employment
+		employment.EmployerName = personname.Normalize(employment.EmployerName)
+		req.Employment = &employment
+	}
+	if req.CoApplicants != nil {
+		coApplicants := make([]CoApplicant, 0, len(req.CoApplicants))
+		for _, v := range req.CoApplicants {
+			v.PersonalDetails = normalizePersonalDetails(v.PersonalDetails)
+			coApplicants = append(coApplicants, v)
+		}
+		req.CoApplicants = coApplicants
+	}
+	return req
+}
+
+func normalizePersonalDetails(p PersonalDetails) PersonalDetails {
+	p.NationalId = nationalid.Normalize(p.NationalId)
+	p.DateOfBirth = strings.TrimSpace(p.DateOfBirth)
+	p.PhoneNumber = strings.TrimSpace(p.PhoneNumber)
+	if address, err := emailaddr.Parse(p.Email); err == nil {
+		p.Email = address.Normalized
+	}
+	p.FullName = personname.Normalize(p.FullName)
+	p.GivenNameTh = personname.Normalize(p.GivenNameTh)
+	p.FamilyNameTh = personname.Normalize(p.FamilyNameTh)
+	p.GivenNameEn = personname.Normalize(p.GivenNameEn)
+	p.FamilyNameEn = personname.Normalize(p.FamilyNameEn)
+
+	if p.FullName == "" {
+		switch {
+		case p.GivenNameEn != "" && p.FamilyNameEn != "":
+			p.FullName = p.GivenNameEn + " " + p.FamilyNameEn
+		case p.GivenNameTh != "" && p.FamilyNameTh != "":
+			p.FullName = p.GivenNameTh + " " + p.FamilyNameTh
 		}
 	}
-	return len(phone) > 0 // Optionally check for non-empty string
+	return p
 }
 
-func isValidEmail(email string) bool {
-	// Simple email regex pattern
-	var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
-	return emailRegex.MatchString(email)
+// isNameInScript reports whether an optional name part is written in
+// script. Empty parts are left to checkMissingFields.
+func isNameInScript(name string, script personname.Script) bool {
+	if name == "" {
+		return true
+	}
+	if personname.Length(name) > MaxNamePartLength {
+		return false
+	}
+	detected, err := personname.DetectScript(name)
+	return err == nil && detected == script
 }
 
-func isPurposeValid(purpose string) bool {
-	for _, v := range PurposeList {
-		if purpose == v {
+func isOneOf(value string, values []string) bool {
+	for _, v := range values {
+		if value == v {
 			return true
 		}
 	}
 	return false
 }
+
+// errorCode is the machine-readable code of a validation error, if it has
+// one.
+func errorCode(err error) string {
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Code
+	}
+	return ""
+}
@@ -1,32 +1,80 @@
 package loancreate
 
 import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/app/notifications"
+	"backend-loan-pre-approval/app/products"
+	"backend-loan-pre-approval/pkg/birthdate"
+	"backend-loan-pre-approval/pkg/creditbureau"
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/emailaddr"
+	"backend-loan-pre-approval/pkg/fx"
+	"backend-loan-pre-approval/pkg/money"
+	"backend-loan-pre-approval/pkg/nationalid"
+	"backend-loan-pre-approval/pkg/scorecard"
 	"bytes"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
 	"gotest.tools/assert"
 )
 
+func newMockProducts(codes ...string) *products.MockService {
+	mockProducts := products.NewMockService()
+	active := []products.Product{}
+	for _, code := range codes {
+		product := products.Product{
+			Code:          code,
+			MinAmount:     money.FromMajor(1000, money.DefaultCurrency),
+			MaxAmount:     money.FromMajor(5000000, money.DefaultCurrency),
+			MinTermMonths: 6,
+			MaxTermMonths: 360,
+			MinRateBps:    0,
+			MaxRateBps:    500,
+		}
+		active = append(active, product)
+		mockProducts.On("GetActiveProduct", mock.Anything, code, mock.Anything).Return(product, nil)
+	}
+	mockProducts.On("GetActiveProduct", mock.Anything, mock.Anything, mock.Anything).Return(products.Product{}, errors.New(products.ErrReasonProductNotFound))
+	mockProducts.On("GetActiveProducts", mock.Anything, mock.Anything).Return(active, nil)
+	return mockProducts
+}
+
+// dateOfBirthForAge gives a date of birth for an applicant who is age today,
+// so ages stay in the range a test relies on.
+func dateOfBirthForAge(age int) string {
+	return birthdate.Today(time.Now()).AddDate(-age, 0, -1).Format(birthdate.Layout)
+}
+
 func Test_SUCCESS(t *testing.T) {
 	mockRepo := NewMockRepo()
-	s := NewService(mockRepo)
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("home"), nil, nil, nil, false, nil)
 	h := NewHandler(s)
 
+	mockTx.On("WithTx", mock.Anything).Return(nil)
 	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
 
 	mockRequestCase01 := HttpRequest{
-		FullName:      "Somkanit Jitsanook",
-		MonthlyIncome: 11000,
-		LoanAmount:    120000,
+		PersonalDetails: PersonalDetails{
+			FullName:    "Somkanit Jitsanook",
+			DateOfBirth: dateOfBirthForAge(25),
+			PhoneNumber: "0851234567",
+			Email:       "demo@example.com",
+		},
+		MonthlyIncome: money.FromMajor(11000, money.DefaultCurrency),
+		LoanAmount:    money.FromMajor(120000, money.DefaultCurrency),
 		LoanPurpose:   "home",
-		Age:           25,
-		PhoneNumber:   "0851234567",
-		Email:         "demo@example.com",
 	}
 
 	b1, err := json.Marshal(mockRequestCase01)
@@ -56,16 +104,706 @@ func Test_SUCCESS(t *testing.T) {
 	assert.Equal(t, messageExpected, response["reason"])
 }
 
+func Test_SUCCESS_ForeignCurrency(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, []fx.Rate{
+		{Currency: "USD", Value: "35.00", EffectiveDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+
+	// 400 USD a month is 14,000 THB, so 150,000 THB is within 12 months
+	body := bytes.NewBufferString(`{
+		"fullName": "John Smith",
+		"monthlyIncome": {"amount": "400.00", "currency": "USD"},
+		"loanAmount": 150000,
+		"loanPurpose": "car",
+		"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+		"phoneNumber": "0851234567",
+		"email": "john@example.com"
+	}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	stored := mockRepo.Calls[0].Arguments.Get(1).(LoanApplicationEntity)
+
+	// Assert
+	assert.Equal(t, MsgReasonSuccess, response["reason"])
+	assert.Equal(t, "USD", stored.MonthlyIncomeCurrency)
+	assert.Assert(t, strings.Contains(string(stored.Decision.JSONText), `"rate":"35.00"`))
+}
+
+func Test_DebtServiceRatioExceeded(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+
+	// 0% product rate: 120,000 over 12 months is 10,000 a month, plus
+	// 5,000 existing on 20,000 income is 75%
+	body := bytes.NewBufferString(`{
+		"fullName": "Somkanit Jitsanook",
+		"monthlyIncome": 20000,
+		"loanAmount": 120000,
+		"loanPurpose": "car",
+		"termMonths": 12,
+		"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+		"phoneNumber": "0851234567",
+		"email": "demo@example.com",
+		"existingObligations": [
+			{"type": "loan", "monthlyPayment": 3000},
+			{"type": "creditCard", "monthlyPayment": "2000.00"}
+		]
+	}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response HttpResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	obligations := mockRepo.Calls[1].Arguments.Get(1).([]LoanObligationEntity)
+
+	// Assert
+	assert.Equal(t, false, response.Eligible)
+	assert.Equal(t, "Debt service ratio 75.00% exceeds the maximum of 60.00%", response.Reason)
+	assert.Equal(t, 2, len(obligations))
+	assert.Equal(t, int64(200000), obligations[1].MonthlyPayment)
+
+	// Assert: 60% of 20,000 leaves 7,000 a month for the new loan
+	reduced, _ := response.Offer.Option(eligibility.OfferReducedAmount)
+	extended, _ := response.Offer.Option(eligibility.OfferExtendedTerm)
+	assert.Equal(t, money.FromMajor(84000, money.DefaultCurrency), reduced.LoanAmount)
+	assert.Equal(t, 18, extended.TermMonths)
+}
+
+func Test_CreditScoreBelowMinimum(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	bureau := creditbureau.NewFileBureau(creditbureau.StubData{
+		Subjects: map[string]creditbureau.StubEntry{
+			"lowscore@example.com": {Status: creditbureau.StatusHit, Score: 540},
+		},
+	})
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("home"), bureau, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateCreditReport", mock.Anything, mock.Anything).Return(nil)
+
+	body := bytes.NewBufferString(`{
+		"fullName": "Somkanit Jitsanook",
+		"monthlyIncome": 11000,
+		"loanAmount": 60000,
+		"loanPurpose": "home",
+		"dateOfBirth": "` + dateOfBirthForAge(25) + `",
+		"phoneNumber": "0851234567",
+		"email": "lowscore@example.com"
+	}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response HttpResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	report := mockRepo.Calls[2].Arguments.Get(1).(CreditReportEntity)
+
+	// Assert
+	assert.Equal(t, false, response.Eligible)
+	assert.Equal(t, "Credit score is below the minimum of 600", response.Reason)
+	assert.Assert(t, response.Offer == nil)
+	assert.Equal(t, int32(540), report.Score.Int32)
+	assert.Assert(t, strings.Contains(string(report.Report.JSONText), `"score":540`))
+}
+
+func Test_ReferredWhenCreditReportUnavailable(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	bureau := creditbureau.NewClient(creditbureau.NewFileBureau(creditbureau.StubData{
+		Default: creditbureau.StubEntry{Status: "error"},
+	}), creditbureau.Options{})
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("home"), bureau, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateCreditReport", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateUnderwritingItem", mock.Anything, mock.Anything).Return(nil)
+
+	body := bytes.NewBufferString(`{
+		"fullName": "Somkanit Jitsanook",
+		"monthlyIncome": 11000,
+		"loanAmount": 60000,
+		"loanPurpose": "home",
+		"dateOfBirth": "` + dateOfBirthForAge(25) + `",
+		"phoneNumber": "0851234567",
+		"email": "demo@example.com"
+	}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response HttpResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, false, response.Eligible)
+	assert.Equal(t, scorecard.OutcomeRefer, response.Outcome)
+	assert.Equal(t, eligibility.MsgReferredNoCreditReport, response.Reason)
+	mockRepo.AssertCalled(t, "CreateUnderwritingItem", mock.Anything, mock.Anything)
+}
+
+func Test_ReferredByScorecard(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	card, err := scorecard.LoadFile("../../configs/scorecard.yaml")
+	if err != nil {
+		panic("error: " + err.Error())
+	}
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, card), newMockProducts("personal"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateUnderwritingItem", mock.Anything, mock.Anything).Return(nil)
+
+	// Passes every rule but scores 385: 300 base + 10 income + 10 age +
+	// 10 purpose + 0 loan-to-income + 40 ratio + 15 no credit score
+	body := bytes.NewBufferString(`{
+		"fullName": "Somkanit Jitsanook",
+		"monthlyIncome": 11000,
+		"loanAmount": 120000,
+		"loanPurpose": "personal",
+		"dateOfBirth": "` + dateOfBirthForAge(22) + `",
+		"phoneNumber": "0851234567",
+		"email": "demo@example.com"
+	}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response HttpResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	stored := mockRepo.Calls[0].Arguments.Get(1).(LoanApplicationEntity)
+
+	// Assert
+	assert.Equal(t, false, response.Eligible)
+	assert.Equal(t, scorecard.OutcomeRefer, response.Outcome)
+	assert.Equal(t, eligibility.MsgReferredForReview, response.Reason)
+	assert.Assert(t, response.Offer == nil)
+	assert.Equal(t, int32(385), stored.RiskScore.Int32)
+	assert.Equal(t, "D", stored.RiskGrade.String)
+	mockRepo.AssertCalled(t, "CreateUnderwritingItem", mock.Anything, mock.MatchedBy(func(item UnderwritingItemEntity) bool {
+		return item.ApplicationId == stored.ApplicationId && item.Status == "pending"
+	}))
+}
+
+func Test_ChallengerRecordedButChampionShown(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	challengerRules := eligibility.DefaultRuleset()
+	challengerRules.Version = "challenger-1"
+	challengerRules.MaxIncomeMultiple = 10
+	experiment, err := eligibility.NewExperiment(eligibility.NewEngine(challengerRules, rates, nil), 100)
+	assert.NilError(t, err)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("home"), nil, experiment, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanExperiment", mock.Anything, mock.Anything).Return(nil)
+
+	// 120,000 is 10.9 months of income: within 12 but not 10
+	body := bytes.NewBufferString(`{
+		"fullName": "Somkanit Jitsanook",
+		"monthlyIncome": 11000,
+		"loanAmount": 120000,
+		"loanPurpose": "home",
+		"dateOfBirth": "` + dateOfBirthForAge(25) + `",
+		"phoneNumber": "0851234567",
+		"email": "demo@example.com"
+	}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response HttpResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	recorded := mockRepo.Calls[2].Arguments.Get(1).(LoanExperimentEntity)
+
+	// Assert
+	assert.Equal(t, true, response.Eligible)
+	assert.Equal(t, "challenger-1", recorded.Experiment)
+	assert.Equal(t, eligibility.ArmChallenger, recorded.Arm)
+	assert.Equal(t, false, recorded.ChallengerEligible)
+	assert.Equal(t, "Loan amount cannot exceed 10 months of income", recorded.ChallengerReason)
+	nobody, err := eligibility.NewExperiment(eligibility.NewEngine(challengerRules, rates, nil), 0)
+	assert.NilError(t, err)
+	assert.Equal(t, eligibility.ArmChampion, nobody.Assign(response.ApplicationId))
+	_, err = eligibility.NewExperiment(eligibility.NewEngine(challengerRules, rates, nil), 101)
+	assert.ErrorContains(t, err, "between 0 and 100")
+	_, err = eligibility.NewExperiment(eligibility.NewEngine(challengerRules, rates, nil), -1)
+	assert.ErrorContains(t, err, "between 0 and 100")
+}
+
+func Test_Simulate_DoesNotPersist(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	body := bytes.NewBufferString(`{
+		"fullName": "Somkanit Jitsanook",
+		"monthlyIncome": 20000,
+		"loanAmount": 120000,
+		"loanPurpose": "car",
+		"termMonths": 12,
+		"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+		"phoneNumber": "0851234567",
+		"email": "demo@example.com",
+		"existingObligations": [{"type": "loan", "monthlyPayment": 5000}]
+	}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/eligibility/simulate", h.EligibilitySimulate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/eligibility/simulate", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, false, response["eligible"])
+	assert.Equal(t, "Debt service ratio 75.00% exceeds the maximum of 60.00%", response["reason"])
+	assert.Equal(t, nil, response["applicationId"])
+	assert.Equal(t, 2, len(response["offerOptions"].([]interface{})))
+	mockTx.AssertNotCalled(t, "WithTx", mock.Anything)
+	assert.Equal(t, 0, len(mockRepo.Calls))
+}
+
+func Test_Simulate_RequiresNationalId(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, true, nil)
+	h := NewHandler(s)
+
+	body := bytes.NewBufferString(`{
+		"fullName": "Somkanit Jitsanook",
+		"monthlyIncome": 20000,
+		"loanAmount": 120000,
+		"loanPurpose": "car",
+		"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+		"phoneNumber": "0851234567",
+		"email": "demo@example.com"
+	}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/eligibility/simulate", h.EligibilitySimulate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/eligibility/simulate", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response HttpBadResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, "missing required fields: nationalId", response.Reason)
+}
+
+func Test_Simulate_DefaultTermEndsBeforeMaxAgeAtMaturity(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("home"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	body := bytes.NewBufferString(`{
+		"fullName": "Somkanit Jitsanook",
+		"monthlyIncome": 100000,
+		"loanAmount": 1000000,
+		"loanPurpose": "home",
+		"dateOfBirth": "` + dateOfBirthForAge(40) + `",
+		"phoneNumber": "0851234567",
+		"email": "demo@example.com"
+	}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/eligibility/simulate", h.EligibilitySimulate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/eligibility/simulate", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response SimulateResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, true, response.Eligible)
+	assert.Equal(t, 311, response.Quote.TermMonths)
+}
+
+func Test_Draft_CreateAllowsMissingFields(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+
+	body := bytes.NewBufferString(`{"fullName": "Somkanit Jitsanook", "loanPurpose": "car"}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loans", h.LoansCreate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loans?draft=true", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	stored := mockRepo.Calls[0].Arguments.Get(1).(LoanApplicationEntity)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"1"`, resp.Header().Get("ETag"))
+	assert.Equal(t, "draft", response["status"])
+	assert.Equal(t, "draft", stored.Status)
+	assert.Equal(t, money.DefaultCurrency, stored.LoanAmountCurrency)
+	assert.Equal(t, false, stored.Eligible.Valid)
+}
+
+func Test_Draft_CreateRejectsNationalIdWithoutProtector(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	body := bytes.NewBufferString(`{"fullName": "Somkanit Jitsanook", "loanPurpose": "car", "nationalId": "1101700156494"}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loans", h.LoansCreate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loans?draft=true", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response HttpBadResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, MsgDraftNotCreated, response.Message)
+	assert.Equal(t, "National ID is not accepted by this service", response.Reason)
+	mockRepo.AssertNotCalled(t, "CreateLoanApplication", mock.Anything, mock.Anything)
+}
+
+func newMockDraft(version int) LoanApplicationEntity {
+	return LoanApplicationEntity{
+		ApplicationId:         "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+		FullName:              "Somkanit Jitsanook",
+		MonthlyIncome:         2000000,
+		MonthlyIncomeCurrency: money.DefaultCurrency,
+		LoanAmountCurrency:    money.DefaultCurrency,
+		LoanPurpose:           "car",
+		DateOfBirth:           sql.NullTime{Time: time.Date(1995, 6, 15, 0, 0, 0, 0, time.UTC), Valid: true},
+		PhoneNumber:           "0851234567",
+		Email:                 "demo@example.com",
+		Timestamp:             time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Status:                "draft",
+		Version:               version,
+	}
+}
+
+func Test_Draft_UpdatePreconditions(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("GetLoanApplicationForUpdate", mock.Anything, mock.Anything).Return(newMockDraft(3), nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.PATCH("/api/v1/loans/:applicationId", h.LoansUpdateDraft)
+
+	send := func(ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "http://0.0.0.0/api/v1/loans/3fa85f64-5717-4562-b3fc-2c963f66afa6", bytes.NewBufferString(`{"loanAmount": 120000}`))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionRequired, send("").Code)
+	assert.Equal(t, http.StatusPreconditionFailed, send(`"2"`).Code)
+	mockRepo.AssertNotCalled(t, "UpdateLoanApplication", mock.Anything, mock.Anything)
+}
+
+func Test_Draft_UpdateMergesFields(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("GetLoanApplicationForUpdate", mock.Anything, mock.Anything).Return(newMockDraft(3), nil)
+	mockRepo.On("GetLoanObligations", mock.Anything, mock.Anything).Return([]LoanObligationEntity{}, nil)
+	mockRepo.On("GetLoanCoApplicants", mock.Anything, mock.Anything).Return([]LoanCoApplicantEntity{}, nil)
+	mockRepo.On("UpdateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("DeleteLoanObligations", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("DeleteLoanCoApplicants", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.PATCH("/api/v1/loans/:applicationId", h.LoansUpdateDraft)
+
+	req := httptest.NewRequest(http.MethodPatch, "http://0.0.0.0/api/v1/loans/3fa85f64-5717-4562-b3fc-2c963f66afa6", bytes.NewBufferString(`{"loanAmount": 120000, "email": "not-an-email"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `W/"3"`)
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	invalidCode := resp.Code
+
+	req = httptest.NewRequest(http.MethodPatch, "http://0.0.0.0/api/v1/loans/3fa85f64-5717-4562-b3fc-2c963f66afa6", bytes.NewBufferString(`{"loanAmount": 120000}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3"`)
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var updated LoanApplicationEntity
+	for _, v := range mockRepo.Calls {
+		if v.Method == "UpdateLoanApplication" {
+			updated = v.Arguments.Get(1).(LoanApplicationEntity)
+		}
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, invalidCode)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"4"`, resp.Header().Get("ETag"))
+	assert.Equal(t, int64(12000000), updated.LoanAmount)
+	assert.Equal(t, int64(2000000), updated.MonthlyIncome)
+	assert.Equal(t, "draft", updated.Status)
+}
+
+func Test_Draft_SubmitEvaluates(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	draft := newMockDraft(2)
+	draft.LoanAmount = 12000000
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("GetLoanApplication", mock.Anything, mock.Anything).Return(draft, nil)
+	mockRepo.On("GetLoanApplicationForUpdate", mock.Anything, mock.Anything).Return(draft, nil)
+	mockRepo.On("GetLoanObligations", mock.Anything, mock.Anything).Return([]LoanObligationEntity{}, nil)
+	mockRepo.On("GetLoanCoApplicants", mock.Anything, mock.Anything).Return([]LoanCoApplicantEntity{}, nil)
+	mockRepo.On("UpdateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("DeleteLoanObligations", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("DeleteLoanCoApplicants", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loans/:applicationId/submit", h.LoansSubmitDraft)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loans/3fa85f64-5717-4562-b3fc-2c963f66afa6/submit", nil)
+	req.Header.Set("If-Match", `"2"`)
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	var submitted LoanApplicationEntity
+	for _, v := range mockRepo.Calls {
+		if v.Method == "UpdateLoanApplication" {
+			submitted = v.Arguments.Get(1).(LoanApplicationEntity)
+		}
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, MsgReasonSuccess, response["reason"])
+	assert.Equal(t, "3fa85f64-5717-4562-b3fc-2c963f66afa6", response["applicationId"])
+	assert.Equal(t, "submitted", submitted.Status)
+	assert.Equal(t, 3, submitted.Version)
+	assert.Equal(t, true, submitted.Eligible.Valid)
+}
+
+func Test_Validate_Purpose(t *testing.T) {
+	mockRepo := NewMockRepo()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, database.NewMockTransactor(), eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("home", "car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockRequestCase := HttpRequest{
+		PersonalDetails: PersonalDetails{
+			FullName:    "Somkanit Jitsanook",
+			DateOfBirth: dateOfBirthForAge(25),
+			PhoneNumber: "0851234567",
+			Email:       "demo@example.com",
+		},
+		MonthlyIncome: money.FromMajor(50000, money.DefaultCurrency),
+		LoanAmount:    money.FromMajor(100000, money.DefaultCurrency),
+		LoanPurpose:   "wedding",
+	}
+
+	b, err := json.Marshal(mockRequestCase)
+	if err != nil {
+		panic("error: " + err.Error())
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", bytes.NewBuffer(b))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	messageExpected := `{"message":"Invalid request body","reason":"Loan purpose must be one of: home, car"}`
+
+	// Assert
+	assert.Equal(t, messageExpected, resp.Body.String())
+	mockRepo.AssertNotCalled(t, "CreateLoanApplication", mock.Anything, mock.Anything)
+}
+
 func Test_Validate_Feild(t *testing.T) {
 	mockService := NewMockService()
 	h := NewHandler(mockService)
 
-	mockService.On("CreateLoanApplication", mock.Anything, mock.Anything).Return("", "", nil)
+	mockService.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(HttpResponse{}, nil)
 
 	mockRequestCase01 := HttpRequest{
-		FullName:      "Somkanit Jitsanook",
-		MonthlyIncome: 5000,
-		LoanAmount:    10000,
+		PersonalDetails: PersonalDetails{
+			FullName: "Somkanit Jitsanook",
+		},
+		MonthlyIncome: money.FromMajor(5000, money.DefaultCurrency),
+		LoanAmount:    money.FromMajor(10000, money.DefaultCurrency),
 		LoanPurpose:   "home",
 	}
 
@@ -85,26 +823,216 @@ func Test_Validate_Feild(t *testing.T) {
 	resp := httptest.NewRecorder()
 	r.ServeHTTP(resp, req)
 
-	messageExpected := `{"message":"Invalid request body","reason":"missing required fields: age, phoneNumber, email"}`
+	messageExpected := `{"message":"Invalid request body","reason":"missing required fields: dateOfBirth, phoneNumber, email"}`
 
 	// Assert
 	assert.Equal(t, messageExpected, resp.Body.String())
 }
 
+func Test_Validate_Name(t *testing.T) {
+	mockService := NewMockService()
+	h := NewHandler(mockService)
+
+	mockService.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(HttpResponse{}, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	cases := []struct {
+		names    string
+		expected int
+	}{
+		// 90 Thai characters are 270 bytes in UTF-8
+		{`"fullName": "` + strings.Repeat("ก", 90) + `"`, http.StatusOK},
+		{`"fullName": "ก"`, http.StatusBadRequest},
+		{`"fullName": "John Smith 3rd"`, http.StatusBadRequest},
+		{`"givenNameTh": "สมคนิต", "familyNameTh": "จิตสนุก"`, http.StatusOK},
+		{`"givenNameEn": "Somkanit", "familyNameEn": "จิตสนุก"`, http.StatusBadRequest},
+		{`"fullName": "Somkanit Jitsanook", "givenNameEn": "Somkanit"`, http.StatusBadRequest},
+	}
+
+	for _, c := range cases {
+		body := bytes.NewBufferString(`{` + c.names + `,
+			"monthlyIncome": 20000,
+			"loanAmount": 120000,
+			"loanPurpose": "car",
+			"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+			"phoneNumber": "0851234567",
+			"email": "demo@example.com"
+		}`)
+
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, c.expected, resp.Code, c.names)
+	}
+}
+
+func Test_NamesNormalizedForStorage(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+
+	body := bytes.NewBufferString(`{
+		"givenNameEn": "  SOMKANIT ",
+		"familyNameEn": "Jitsanook",
+		"givenNameTh": "สมคนิต",
+		"familyNameTh": "จิต  สนุก",
+		"monthlyIncome": 20000,
+		"loanAmount": 120000,
+		"loanPurpose": "car",
+		"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+		"phoneNumber": "0851234567",
+		"email": "demo@example.com"
+	}`)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	stored := mockRepo.Calls[0].Arguments.Get(1).(LoanApplicationEntity)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "SOMKANIT Jitsanook", stored.FullName)
+	assert.Equal(t, "somkanit jitsanook", stored.FullNameKey)
+	assert.Equal(t, "จิต สนุก", stored.FamilyNameTh)
+}
+
+func Test_PhoneNormalizedForStorage(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	send := func(phoneNumber string) *httptest.ResponseRecorder {
+		body := bytes.NewBufferString(`{
+			"fullName": "Somkanit Jitsanook",
+			"monthlyIncome": 20000,
+			"loanAmount": 120000,
+			"loanPurpose": "car",
+			"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+			"phoneNumber": "` + phoneNumber + `",
+			"email": "demo@example.com"
+		}`)
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	invalid := send("12345")
+	local := send("085-123-4567")
+	foreign := send("+44 20 7946 0958")
+
+	storedLocal := mockRepo.Calls[0].Arguments.Get(1).(LoanApplicationEntity)
+	storedForeign := mockRepo.Calls[2].Arguments.Get(1).(LoanApplicationEntity)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	assert.Equal(t, http.StatusOK, local.Code)
+	assert.Equal(t, http.StatusOK, foreign.Code)
+	assert.Equal(t, "085-123-4567", storedLocal.PhoneNumber)
+	assert.Equal(t, "+66851234567", storedLocal.PhoneE164)
+	assert.Equal(t, "mobile", storedLocal.PhoneType)
+	assert.Equal(t, "+442079460958", storedForeign.PhoneE164)
+	assert.Equal(t, "GB", storedForeign.PhoneRegion)
+	assert.Equal(t, "landline", storedForeign.PhoneType)
+}
+
+func Test_NationalIdSealedAndIndexed(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	nationalIds, _ := nationalid.NewProtector("ZBnuwcjoHClycegcsXwqQtqya7QvoEN4Z0U8Zy7K4/8=", "M3B6V2BzyUluf/YGIVpXhL29p93sfrhGpYpB2refa+8=", false, true)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nationalIds, true, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	send := func(nationalId string) *httptest.ResponseRecorder {
+		body := bytes.NewBufferString(`{
+			"nationalId": "` + nationalId + `",
+			"fullName": "Somkanit Jitsanook",
+			"monthlyIncome": 20000,
+			"loanAmount": 120000,
+			"loanPurpose": "car",
+			"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+			"phoneNumber": "0851234567",
+			"email": "demo@example.com"
+		}`)
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	missing := send("")
+	badChecksum := send("1-1017-00156-49-5")
+	ok := send("1-1017-00156-49-4")
+
+	stored := mockRepo.Calls[0].Arguments.Get(1).(LoanApplicationEntity)
+	opened, err := nationalIds.Open(stored.NationalIdEncrypted)
+	assert.NilError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, missing.Code)
+	assert.Assert(t, strings.Contains(missing.Body.String(), "missing required fields: nationalId"))
+	assert.Equal(t, http.StatusBadRequest, badChecksum.Code)
+	assert.Equal(t, http.StatusOK, ok.Code)
+	assert.Equal(t, "1101700156494", opened)
+	assert.Equal(t, nationalIds.Index("1101700156494"), stored.NationalIdHash.String)
+	assert.Assert(t, !strings.Contains(string(stored.NationalIdEncrypted), "1101700156494"))
+}
+
 func Test_Validate_Email(t *testing.T) {
 	mockService := NewMockService()
 	h := NewHandler(mockService)
 
-	mockService.On("CreateLoanApplication", mock.Anything, mock.Anything).Return("", "", nil)
+	mockService.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(HttpResponse{}, nil)
 
 	mockRequestCase := HttpRequest{
-		FullName:      "Somkanit Jitsanook",
-		MonthlyIncome: 5000,
-		LoanAmount:    10000,
+		PersonalDetails: PersonalDetails{
+			FullName:    "Somkanit Jitsanook",
+			DateOfBirth: dateOfBirthForAge(25),
+			PhoneNumber: "0851234567",
+			Email:       "demoexample.com",
+		},
+		MonthlyIncome: money.FromMajor(5000, money.DefaultCurrency),
+		LoanAmount:    money.FromMajor(10000, money.DefaultCurrency),
 		LoanPurpose:   "home",
-		Age:           25,
-		PhoneNumber:   "0851234567",
-		Email:         "demoexample.com",
 	}
 
 	b, err := json.Marshal(mockRequestCase)
@@ -123,8 +1051,415 @@ func Test_Validate_Email(t *testing.T) {
 	resp := httptest.NewRecorder()
 	r.ServeHTTP(resp, req)
 
-	messageExpected := `{"message":"Invalid request body","reason":"email must be a valid email"}`
+	messageExpected := `{"message":"Invalid request body","reason":"Email must be a valid email address"}`
 
 	// Assert
 	assert.Equal(t, messageExpected, resp.Body.String())
 }
+
+func Test_Validate_DisposableEmail(t *testing.T) {
+	mockService := NewMockService()
+	h := NewHandler(mockService)
+
+	mockService.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(HttpResponse{}, nil)
+
+	blocklist, _ := emailaddr.NewBlocklist([]string{"mailinator.com"})
+	emailaddr.SetBlocklist(blocklist)
+	defer emailaddr.SetBlocklist(nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	send := func(email string) *httptest.ResponseRecorder {
+		body := bytes.NewBufferString(`{
+			"fullName": "Somkanit Jitsanook",
+			"monthlyIncome": 20000,
+			"loanAmount": 120000,
+			"loanPurpose": "car",
+			"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+			"phoneNumber": "0851234567",
+			"email": "` + email + `"
+		}`)
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	disposable := send("someone@Mailinator.com")
+	idn := send("Someone@BÜCHER.de")
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(disposable.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	submitted := mockService.Calls[0].Arguments.Get(1).(HttpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, disposable.Code)
+	assert.Equal(t, CodeDisposableEmail, response["code"])
+	assert.Equal(t, "Email domain belongs to a disposable email provider", response["reason"])
+	assert.Equal(t, http.StatusOK, idn.Code)
+	assert.Equal(t, "Someone@xn--bcher-kva.de", submitted.Email)
+}
+
+func Test_Validate_DateOfBirth(t *testing.T) {
+	mockService := NewMockService()
+	h := NewHandler(mockService)
+
+	mockService.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(HttpResponse{}, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	send := func(dateOfBirth string) *httptest.ResponseRecorder {
+		body := bytes.NewBufferString(`{
+			"fullName": "Somkanit Jitsanook",
+			"monthlyIncome": 20000,
+			"loanAmount": 120000,
+			"loanPurpose": "car",
+			"dateOfBirth": "` + dateOfBirth + `",
+			"phoneNumber": "0851234567",
+			"email": "demo@example.com"
+		}`)
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	wrongFormat := send("12/04/1999")
+	future := send(time.Now().AddDate(0, 0, 2).Format(birthdate.Layout))
+	valid := send("1999-04-12")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, wrongFormat.Code)
+	assert.Assert(t, strings.Contains(wrongFormat.Body.String(), "Date of birth must be a past date"))
+	assert.Equal(t, http.StatusBadRequest, future.Code)
+	assert.Equal(t, http.StatusOK, valid.Code)
+}
+
+func Test_AgeAtMaturityExceeded(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	send := func(termMonths string) HttpResponse {
+		body := bytes.NewBufferString(`{
+			"fullName": "Somkanit Jitsanook",
+			"monthlyIncome": 20000,
+			"loanAmount": 120000,
+			"loanPurpose": "car",
+			"termMonths": ` + termMonths + `,
+			"dateOfBirth": "` + dateOfBirthForAge(50) + `",
+			"phoneNumber": "0851234567",
+			"email": "demo@example.com"
+		}`)
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+
+		var response HttpResponse
+		if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+			panic("error: " + err.Error())
+		}
+		return response
+	}
+
+	// 50 today, so 70 after 20 years and 60 after 10.
+	long := send("240")
+	short := send("120")
+
+	stored := mockRepo.Calls[0].Arguments.Get(1).(LoanApplicationEntity)
+
+	// Assert
+	assert.Equal(t, false, long.Eligible)
+	assert.Equal(t, "Age at the end of the loan term cannot exceed 65", long.Reason)
+	assert.Equal(t, true, short.Eligible)
+	assert.Equal(t, 50, stored.Age)
+	assert.Equal(t, dateOfBirthForAge(50), stored.DateOfBirth.Time.Format(birthdate.Layout))
+}
+
+func Test_Validate_Employment(t *testing.T) {
+	mockService := NewMockService()
+	h := NewHandler(mockService)
+
+	mockService.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(HttpResponse{}, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	send := func(employment string) *httptest.ResponseRecorder {
+		body := bytes.NewBufferString(`{
+			"fullName": "Somkanit Jitsanook",
+			"monthlyIncome": 20000,
+			"loanAmount": 120000,
+			"loanPurpose": "car",
+			"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+			"phoneNumber": "0851234567",
+			"email": "demo@example.com",
+			"employment": ` + employment + `
+		}`)
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	noEmployer := send(`{"type": "salaried", "tenureMonths": 12}`)
+	unknownType := send(`{"type": "student", "tenureMonths": 12}`)
+	unknownFrequency := send(`{"type": "retired", "incomeFrequency": "yearly"}`)
+	valid := send(`{"type": "selfEmployed", "tenureMonths": 30, "incomeFrequency": "irregular"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, noEmployer.Code)
+	assert.Assert(t, strings.Contains(noEmployer.Body.String(), "missing required fields: employment.employerName"))
+	assert.Equal(t, http.StatusBadRequest, unknownType.Code)
+	assert.Equal(t, http.StatusBadRequest, unknownFrequency.Code)
+	assert.Equal(t, http.StatusOK, valid.Code)
+}
+
+func Test_EmploymentRules(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	rules := eligibility.DefaultRuleset()
+	rules.Employment = map[string]eligibility.EmploymentRule{
+		"salaried":     {MinTenureMonths: 6},
+		"selfemployed": {IncomeHaircutBps: 3000},
+	}
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(rules, rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	send := func(employment string) HttpResponse {
+		body := bytes.NewBufferString(`{
+			"fullName": "Somkanit Jitsanook",
+			"monthlyIncome": 20000,
+			"loanAmount": 200000,
+			"loanPurpose": "car",
+			"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+			"phoneNumber": "0851234567",
+			"email": "demo@example.com",
+			"employment": ` + employment + `
+		}`)
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+
+		var response HttpResponse
+		if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+			panic("error: " + err.Error())
+		}
+		return response
+	}
+
+	// 200,000 is within 12 months of 20,000 but not of the 14,000 left
+	// after a 30% haircut.
+	newHire := send(`{"type": "salaried", "employerName": "  Siam   Logistics ", "tenureMonths": 3, "incomeFrequency": "monthly"}`)
+	salaried := send(`{"type": "salaried", "employerName": "Siam Logistics", "tenureMonths": 24}`)
+	selfEmployed := send(`{"type": "selfEmployed", "tenureMonths": 24}`)
+
+	stored := mockRepo.Calls[0].Arguments.Get(1).(LoanApplicationEntity)
+
+	// Assert
+	assert.Equal(t, false, newHire.Eligible)
+	assert.Equal(t, "Employment tenure must be at least 6 months for salaried applicants", newHire.Reason)
+	assert.Equal(t, true, salaried.Eligible)
+	assert.Equal(t, false, selfEmployed.Eligible)
+	assert.Equal(t, "Loan amount cannot exceed 12 months of income", selfEmployed.Reason)
+	assert.Equal(t, "salaried", stored.EmploymentType)
+	assert.Equal(t, "Siam Logistics", stored.EmployerName)
+	assert.Equal(t, 3, stored.EmploymentTenureMonths)
+	assert.Equal(t, "monthly", stored.IncomeFrequency)
+}
+
+func Test_Validate_CoApplicants(t *testing.T) {
+	mockService := NewMockService()
+	h := NewHandler(mockService)
+
+	mockService.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(HttpResponse{}, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	send := func(coApplicants string) *httptest.ResponseRecorder {
+		body := bytes.NewBufferString(`{
+			"nationalId": "1101700156494",
+			"fullName": "Somkanit Jitsanook",
+			"monthlyIncome": 20000,
+			"loanAmount": 120000,
+			"loanPurpose": "car",
+			"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+			"phoneNumber": "0851234567",
+			"email": "demo@example.com",
+			"coApplicants": ` + coApplicants + `
+		}`)
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	person := `"fullName": "Malee Jitsanook", "dateOfBirth": "` + dateOfBirthForAge(28) + `", "phoneNumber": "0859876543", "email": "malee@example.com"`
+	noIncome := send(`[{"role": "coBorrower", ` + person + `}]`)
+	unknownRole := send(`[{"role": "spouse", "monthlyIncome": 15000, ` + person + `}]`)
+	badEmail := send(`[{"role": "guarantor", "fullName": "Malee Jitsanook", "dateOfBirth": "` + dateOfBirthForAge(28) + `", "phoneNumber": "0859876543", "email": "maleeexample.com"}]`)
+	sameAsApplicant := send(`[{"role": "guarantor", "nationalId": "1-1017-00156-49-4", ` + person + `}]`)
+	valid := send(`[{"role": "guarantor", "nationalId": "1101700156508", ` + person + `}]`)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, noIncome.Code)
+	assert.Assert(t, strings.Contains(noIncome.Body.String(), "missing required fields: coApplicants[0].monthlyIncome"))
+	assert.Equal(t, http.StatusBadRequest, unknownRole.Code)
+	assert.Equal(t, http.StatusBadRequest, badEmail.Code)
+	assert.Assert(t, strings.Contains(badEmail.Body.String(), "coApplicants[0]: "))
+	assert.Equal(t, http.StatusBadRequest, sameAsApplicant.Code)
+	assert.Assert(t, strings.Contains(sameAsApplicant.Body.String(), "Co-applicant must not be the applicant"))
+	assert.Equal(t, http.StatusOK, valid.Code)
+}
+
+func Test_CoBorrowerIncomeCombined(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, nil)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanCoApplicants", mock.Anything, mock.Anything).Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	send := func(role string) HttpResponse {
+		body := bytes.NewBufferString(`{
+			"fullName": "Somkanit Jitsanook",
+			"monthlyIncome": 10000,
+			"loanAmount": 200000,
+			"loanPurpose": "car",
+			"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+			"phoneNumber": "0851234567",
+			"email": "demo@example.com",
+			"coApplicants": [{
+				"role": "` + role + `",
+				"fullName": "Malee Jitsanook",
+				"dateOfBirth": "` + dateOfBirthForAge(28) + `",
+				"phoneNumber": "0859876543",
+				"email": "malee@example.com",
+				"monthlyIncome": 10000
+			}]
+		}`)
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+
+		var response HttpResponse
+		if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+			panic("error: " + err.Error())
+		}
+		return response
+	}
+
+	// 200,000 is more than 12 months of 10,000 but within 12 months of the
+	// 20,000 earned together; a guarantor's income does not count.
+	coBorrower := send(RoleCoBorrower)
+	guarantor := send(RoleGuarantor)
+
+	var stored []LoanCoApplicantEntity
+	for _, c := range mockRepo.Calls {
+		if c.Method == "CreateLoanCoApplicants" {
+			stored = c.Arguments.Get(1).([]LoanCoApplicantEntity)
+			break
+		}
+	}
+
+	// Assert
+	assert.Equal(t, true, coBorrower.Eligible)
+	assert.Equal(t, false, guarantor.Eligible)
+	assert.Equal(t, "Loan amount cannot exceed 12 months of income", guarantor.Reason)
+	assert.Equal(t, 1, len(stored))
+	assert.Equal(t, RoleCoBorrower, stored[0].Role)
+	assert.Equal(t, "+66859876543", stored[0].PhoneE164)
+	assert.Equal(t, money.FromMajor(10000, money.DefaultCurrency), stored[0].Income())
+}
+
+func Test_DecisionNotified(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockTx := database.NewMockTransactor()
+	mockNotifier := notifications.NewMockService()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), newMockProducts("car"), nil, nil, nil, false, mockNotifier)
+	h := NewHandler(s)
+
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanApplication", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanObligations", mock.Anything, mock.Anything).Return(nil)
+	mockNotifier.On("Notify", mock.Anything, mock.Anything, notifications.EventApproved).Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loan", h.LoansCreate)
+
+	send := func(locale string) *httptest.ResponseRecorder {
+		body := bytes.NewBufferString(`{
+			"fullName": "Somkanit Jitsanook",
+			"monthlyIncome": 20000,
+			"loanAmount": 120000,
+			"loanPurpose": "car",
+			"dateOfBirth": "` + dateOfBirthForAge(30) + `",
+			"phoneNumber": "0851234567",
+			"email": "demo@example.com",
+			"locale": "` + locale + `"
+		}`)
+		req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loan", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	unknownLocale := send("fr")
+	resp := send(" EN ")
+
+	stored := mockRepo.Calls[0].Arguments.Get(1).(LoanApplicationEntity)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, unknownLocale.Code)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, notifications.LocaleEnglish, stored.Locale)
+	mockNotifier.AssertCalled(t, "Notify", mock.Anything, stored.ApplicationId, notifications.EventApproved)
+	mockNotifier.AssertNumberOfCalls(t, "Notify", 1)
+}
@@ -1,42 +1,192 @@
 package loancreate
 
+import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/pkg/amortization"
+	"backend-loan-pre-approval/pkg/money"
+)
+
 // ======= sample request ======== //
 // {
+// 	"nationalId": "1-1017-00156-49-4",
 // 	"fullName": "Somkanit Jitsanook",
+// 	"givenNameTh": "สมคนิต",
+// 	"familyNameTh": "จิตสนุก",
+// 	"givenNameEn": "Somkanit",
+// 	"familyNameEn": "Jitsanook",
 // 	"monthlyIncome": 5000,
 // 	"loanAmount": 10000,
 // 	"loanPurpose": "home",
-// 	"age": 25,
+// 	"termMonths": 24,
+// 	"dateOfBirth": "1999-04-12",
 // 	"phoneNumber": "0851234567",
-// 	"email": "demo@example.com"
+// 	"email": "demo@example.com",
+// 	"locale": "th",
+// 	"employment": {
+// 		"type": "salaried",
+// 		"employerName": "Siam Logistics Co., Ltd.",
+// 		"tenureMonths": 38,
+// 		"incomeFrequency": "monthly"
+// 	},
+// 	"coApplicants": [
+// 		{
+// 			"role": "coBorrower",
+// 			"fullName": "Suda Jitsanook",
+// 			"dateOfBirth": "1997-01-30",
+// 			"phoneNumber": "0869876543",
+// 			"email": "suda@example.com",
+// 			"monthlyIncome": 18000
+// 		}
+// 	],
+// 	"existingObligations": [
+// 		{"type": "loan", "monthlyPayment": 3500},
+// 		{"type": "creditCard", "monthlyPayment": 1200}
+// 	]
 // }
+//
+// Amounts also take "5000.50" or {"amount": "5000.50", "currency": "THB"}.
+// Required fields are listed in checkMissingFields and value rules in
+// checkValueCondition.
 
 type HttpRequest struct {
-	FullName      string `json:"fullName"`
-	MonthlyIncome int    `json:"monthlyIncome"`
-	LoanAmount    int    `json:"loanAmount"`
-	LoanPurpose   string `json:"loanPurpose"`
-	Age           int    `json:"age"`
-	PhoneNumber   string `json:"phoneNumber"`
-	Email         string `json:"email"`
+	PersonalDetails
+	MonthlyIncome money.Money `json:"monthlyIncome"`
+	LoanAmount    money.Money `json:"loanAmount"`
+	LoanPurpose   string      `json:"loanPurpose"`
+	TermMonths    int         `json:"termMonths"`
+	Employment    *Employment `json:"employment,omitempty"`
+	Locale        string      `json:"locale,omitempty"`
+
+	CoApplicants        []CoApplicant `json:"coApplicants,omitempty"`
+	ExistingObligations []Obligation  `json:"existingObligations"`
+}
+
+// PersonalDetails are validated and stored the same way for the applicant
+// and for every co-applicant.
+type PersonalDetails struct {
+	NationalId   string `json:"nationalId,omitempty"`
+	FullName     string `json:"fullName"`
+	GivenNameTh  string `json:"givenNameTh,omitempty"`
+	FamilyNameTh string `json:"familyNameTh,omitempty"`
+	GivenNameEn  string `json:"givenNameEn,omitempty"`
+	FamilyNameEn string `json:"familyNameEn,omitempty"`
+	DateOfBirth  string `json:"dateOfBirth"`
+	PhoneNumber  string `json:"phoneNumber"`
+	Email        string `json:"email"`
+}
+
+// CoApplicant is another person on the application; Role is one of
+// CoApplicantRoles. A co-borrower's monthlyIncome is added to the
+// applicant's for eligibility. A guarantor only backs the loan, so their
+// income is recorded but not counted and may be left out.
+type CoApplicant struct {
+	Role string `json:"role"`
+	PersonalDetails
+	MonthlyIncome money.Money `json:"monthlyIncome"`
+}
+
+// Employment is the applicant's main source of income. IncomeFrequency is
+// one of IncomeFrequencies and informs underwriters only; monthlyIncome is
+// always the monthly equivalent.
+type Employment struct {
+	Type            string `json:"type"`
+	EmployerName    string `json:"employerName,omitempty"`
+	TenureMonths    int    `json:"tenureMonths"`
+	IncomeFrequency string `json:"incomeFrequency,omitempty"`
+}
+
+// Obligation is an existing monthly debt payment; Type is one of
+// ObligationTypes.
+type Obligation struct {
+	Type           string      `json:"type"`
+	MonthlyPayment money.Money `json:"monthlyPayment"`
 }
 
 // ======== sample response ======== //
 // {
 // 	"applicationId": "3fa85f64-5717-4562-b3fc-2c963f66afa6",
 // 	"eligible": true,
+// 	"outcome": "approve",
 // 	"reason": "Eligible under base rules",
-// 	"timestamp": "2025-07-19T19:34:56+07:00"
+// 	"timestamp": "2025-07-19T19:34:56+07:00",
+// 	"quote": {
+// 		"principal": {"amount": "10000.00", "currency": "THB"},
+// 		"annualRateBps": 325,
+// 		"termMonths": 24,
+// 		"monthlyInstallment": {"amount": "430.92", "currency": "THB"},
+// 		"totalInterest": {"amount": "342.02", "currency": "THB"},
+// 		"totalPayment": {"amount": "10342.02", "currency": "THB"},
+// 		"schedule": [...]
+// 	},
+// 	"checks": [
+// 		{"rule": "minMonthlyIncome", "passed": true},
+// 		...
+// 		{"rule": "debtServiceRatio", "passed": true, "value": "8.62", "limit": "60.00"}
+// 	]
 // }
+//
+// A declined application that only failed on amount or term also carries
+// "offer": {"options": [{"type": "reducedAmount", ...}], "expiresAt": "..."},
+// which can be accepted at POST /api/v1/loans/:applicationId/offer/accept.
 
 type HttpResponse struct {
+	ApplicationId string                 `json:"applicationId"`
+	Eligible      bool                   `json:"eligible"`
+	Outcome       string                 `json:"outcome"`
+	Reason        string                 `json:"reason"`
+	Timestamp     string                 `json:"timestamp"`
+	Quote         *amortization.Schedule `json:"quote,omitempty"`
+	Checks        []eligibility.Check    `json:"checks,omitempty"`
+	Offer         *eligibility.Offer     `json:"offer,omitempty"`
+}
+
+// ======== sample simulate response ======== //
+// {
+// 	"eligible": false,
+// 	"outcome": "decline",
+// 	"reason": "Debt service ratio 75.00% exceeds the maximum of 60.00%",
+// 	"quote": {...},
+// 	"decision": {"rulesetVersion": "base-1", "checks": [...], ...},
+// 	"offerOptions": [{"type": "reducedAmount", ...}]
+// }
+//
+// Simulations are never stored, so offer options cannot be accepted; the
+// applicant has to submit the application first.
+
+type SimulateResponse struct {
+	Eligible     bool                      `json:"eligible"`
+	Outcome      string                    `json:"outcome"`
+	Reason       string                    `json:"reason"`
+	Quote        *amortization.Schedule    `json:"quote,omitempty"`
+	Decision     eligibility.Decision      `json:"decision"`
+	OfferOptions []eligibility.OfferOption `json:"offerOptions,omitempty"`
+}
+
+// ======== sample draft response ======== //
+// {
+// 	"applicationId": "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+// 	"status": "draft",
+// 	"version": 2,
+// 	"fullName": "Somkanit Jitsanook",
+// 	"monthlyIncome": {"amount": "5000.00", "currency": "THB"},
+// 	...
+// }
+//
+// Drafts are created with POST /api/v1/loans?draft=true and may leave any
+// field empty. PATCH /api/v1/loans/:applicationId merges the fields present
+// in the body into the draft, and POST /api/v1/loans/:applicationId/submit
+// evaluates it and returns an HttpResponse. Both require an If-Match header
+// with the ETag of the last response, which is the quoted version.
+
+type DraftResponse struct {
 	ApplicationId string `json:"applicationId"`
-	Eligible      bool   `json:"eligible"`
-	Reason        string `json:"reason"`
-	Timestamp     string `json:"timestamp"`
+	Status        string `json:"status"`
+	Version       int    `json:"version"`
+	HttpRequest
 }
 
 type HttpBadResponse struct {
 	Message string `json:"message"`
 	Reason  string `json:"reason"`
+	Code    string `json:"code,omitempty"`
 }
@@ -1,7 +1,10 @@
 package loancreate
 
 import (
+	"backend-loan-pre-approval/pkg/store"
 	"context"
+	"database/sql"
+	"errors"
 	"log"
 
 	"github.com/jmoiron/sqlx"
@@ -9,34 +12,164 @@ import (
 
 type Repository interface {
 	CreateLoanApplication(ctx context.Context, LoanApplication LoanApplicationEntity) error
+	CreateLoanObligations(ctx context.Context, obligations []LoanObligationEntity) error
+	CreateLoanCoApplicants(ctx context.Context, coApplicants []LoanCoApplicantEntity) error
+	CreateCreditReport(ctx context.Context, report CreditReportEntity) error
+	CreateUnderwritingItem(ctx context.Context, item UnderwritingItemEntity) error
+	CreateLoanExperiment(ctx context.Context, experiment LoanExperimentEntity) error
+	GetLoanApplication(ctx context.Context, applicationId string) (LoanApplicationEntity, error)
+	GetLoanApplicationForUpdate(ctx context.Context, applicationId string) (LoanApplicationEntity, error)
+	GetLoanObligations(ctx context.Context, applicationId string) ([]LoanObligationEntity, error)
+	GetLoanCoApplicants(ctx context.Context, applicationId string) ([]LoanCoApplicantEntity, error)
+	UpdateLoanApplication(ctx context.Context, LoanApplication LoanApplicationEntity) error
+	DeleteLoanObligations(ctx context.Context, applicationId string) error
+	DeleteLoanCoApplicants(ctx context.Context, applicationId string) error
 }
 
 type RepositoryImpl struct {
-	db *sqlx.DB
+	queries *store.Queries
 }
 
 func NewRepository(db *sqlx.DB) Repository {
 	return &RepositoryImpl{
-		db: db,
+		queries: store.New(db),
 	}
 }
 
 func (r *RepositoryImpl) CreateLoanApplication(ctx context.Context, LoanApplication LoanApplicationEntity) error {
 
-	sql := `INSERT INTO loan_applications (
-		application_id, full_name, monthly_income, loan_amount,
-		loan_purpose, age, phone_number, email, timestamp
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	_, err := r.db.ExecContext(
-		ctx, sql, LoanApplication.ApplicationId, LoanApplication.FullName,
-		LoanApplication.MonthlyIncome, LoanApplication.LoanAmount,
-		LoanApplication.LoanPurpose, LoanApplication.Age,
-		LoanApplication.PhoneNumber, LoanApplication.Email,
-		LoanApplication.Timestamp,
-	)
+	if err := r.queries.InsertLoanApplication(ctx, LoanApplication); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) CreateLoanObligations(ctx context.Context, obligations []LoanObligationEntity) error {
+
+	if err := r.queries.InsertLoanObligations(ctx, obligations); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) CreateCreditReport(ctx context.Context, report CreditReportEntity) error {
+
+	if err := r.queries.InsertCreditReport(ctx, report); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) CreateUnderwritingItem(ctx context.Context, item UnderwritingItemEntity) error {
+
+	if err := r.queries.InsertUnderwritingItem(ctx, item); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) CreateLoanExperiment(ctx context.Context, experiment LoanExperimentEntity) error {
+
+	if err := r.queries.InsertLoanExperiment(ctx, experiment); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) GetLoanApplication(ctx context.Context, applicationId string) (LoanApplicationEntity, error) {
+
+	loanApplication, err := r.queries.GetLoanApplication(ctx, applicationId)
 	if err != nil {
-		finalQuery := r.db.Rebind(sql)
-		log.Println("sql: ", finalQuery)
+		if errors.Is(err, sql.ErrNoRows) {
+			return LoanApplicationEntity{}, ErrApplicationNotFound
+		}
+		log.Println("err: ", err)
+		return LoanApplicationEntity{}, err
+	}
+
+	return loanApplication, nil
+}
+
+func (r *RepositoryImpl) GetLoanApplicationForUpdate(ctx context.Context, applicationId string) (LoanApplicationEntity, error) {
+
+	loanApplication, err := r.queries.GetLoanApplicationForUpdate(ctx, applicationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return LoanApplicationEntity{}, ErrApplicationNotFound
+		}
+		log.Println("err: ", err)
+		return LoanApplicationEntity{}, err
+	}
+
+	return loanApplication, nil
+}
+
+func (r *RepositoryImpl) GetLoanObligations(ctx context.Context, applicationId string) ([]LoanObligationEntity, error) {
+
+	obligations, err := r.queries.ListLoanObligations(ctx, applicationId)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return obligations, nil
+}
+
+func (r *RepositoryImpl) UpdateLoanApplication(ctx context.Context, LoanApplication LoanApplicationEntity) error {
+
+	if err := r.queries.UpdateLoanApplication(ctx, LoanApplication); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) DeleteLoanObligations(ctx context.Context, applicationId string) error {
+
+	if err := r.queries.DeleteLoanObligations(ctx, applicationId); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) CreateLoanCoApplicants(ctx context.Context, coApplicants []LoanCoApplicantEntity) error {
+
+	if err := r.queries.InsertLoanCoApplicants(ctx, coApplicants); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) GetLoanCoApplicants(ctx context.Context, applicationId string) ([]LoanCoApplicantEntity, error) {
+
+	coApplicants, err := r.queries.ListLoanCoApplicants(ctx, applicationId)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return coApplicants, nil
+}
+
+func (r *RepositoryImpl) DeleteLoanCoApplicants(ctx context.Context, applicationId string) error {
+
+	if err := r.queries.DeleteLoanCoApplicants(ctx, applicationId); err != nil {
+		log.Println("err: ", err)
 		return err
 	}
 
@@ -20,3 +20,63 @@ func (m *MockRepo) CreateLoanApplication(ctx context.Context, LoanApplication Lo
 	args := m.Called(ctx, LoanApplication)
 	return args.Error(0)
 }
+
+func (m *MockRepo) CreateLoanObligations(ctx context.Context, obligations []LoanObligationEntity) error {
+	args := m.Called(ctx, obligations)
+	return args.Error(0)
+}
+
+func (m *MockRepo) CreateCreditReport(ctx context.Context, report CreditReportEntity) error {
+	args := m.Called(ctx, report)
+	return args.Error(0)
+}
+
+func (m *MockRepo) CreateUnderwritingItem(ctx context.Context, item UnderwritingItemEntity) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockRepo) CreateLoanExperiment(ctx context.Context, experiment LoanExperimentEntity) error {
+	args := m.Called(ctx, experiment)
+	return args.Error(0)
+}
+
+func (m *MockRepo) GetLoanApplication(ctx context.Context, applicationId string) (LoanApplicationEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).(LoanApplicationEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetLoanApplicationForUpdate(ctx context.Context, applicationId string) (LoanApplicationEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).(LoanApplicationEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetLoanObligations(ctx context.Context, applicationId string) ([]LoanObligationEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).([]LoanObligationEntity), args.Error(1)
+}
+
+func (m *MockRepo) UpdateLoanApplication(ctx context.Context, LoanApplication LoanApplicationEntity) error {
+	args := m.Called(ctx, LoanApplication)
+	return args.Error(0)
+}
+
+func (m *MockRepo) DeleteLoanObligations(ctx context.Context, applicationId string) error {
+	args := m.Called(ctx, applicationId)
+	return args.Error(0)
+}
+
+func (m *MockRepo) CreateLoanCoApplicants(ctx context.Context, coApplicants []LoanCoApplicantEntity) error {
+	args := m.Called(ctx, coApplicants)
+	return args.Error(0)
+}
+
+func (m *MockRepo) GetLoanCoApplicants(ctx context.Context, applicationId string) ([]LoanCoApplicantEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).([]LoanCoApplicantEntity), args.Error(1)
+}
+
+func (m *MockRepo) DeleteLoanCoApplicants(ctx context.Context, applicationId string) error {
+	args := m.Called(ctx, applicationId)
+	return args.Error(0)
+}
@@ -1,64 +1,942 @@
 package loancreate
 
 import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/app/notifications"
+	"backend-loan-pre-approval/app/products"
+	"backend-loan-pre-approval/pkg/amortization"
+	"backend-loan-pre-approval/pkg/birthdate"
+	"backend-loan-pre-approval/pkg/creditbureau"
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/money"
+	"backend-loan-pre-approval/pkg/nationalid"
+	"backend-loan-pre-approval/pkg/personname"
+	"backend-loan-pre-approval/pkg/phone"
+	"backend-loan-pre-approval/pkg/scorecard"
+	"backend-loan-pre-approval/pkg/store"
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx/types"
 )
 
 type Service interface {
-	CreateLoanApplication(ctx context.Context, req HttpRequest) (string, string, error)
+	CreateLoanApplication(ctx context.Context, req HttpRequest) (HttpResponse, error)
+	SimulateLoanApplication(ctx context.Context, req HttpRequest) (SimulateResponse, error)
+	CreateDraft(ctx context.Context, req HttpRequest) (DraftResponse, error)
+	UpdateDraft(ctx context.Context, applicationId string, version int, patch []byte) (DraftResponse, error)
+	SubmitDraft(ctx context.Context, applicationId string, version int) (HttpResponse, error)
+}
+
+// ValidationError is returned for requests that are well-formed but cannot be
+// evaluated, e.g. an amount outside the accepted range once converted.
+type ValidationError struct {
+	// Code is set for failures a client is expected to handle specially;
+	// see the Code constants.
+	Code   string
+	Reason string
+}
+
+func (e ValidationError) Error() string {
+	return e.Reason
+}
+
+// asValidationError keeps the code of an error that already is a
+// ValidationError.
+func asValidationError(err error) error {
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr
+	}
+	return ValidationError{Reason: err.Error()}
 }
 
 type ServiceImopl struct {
 	repository Repository
+	transactor database.Transactor
+	engine     *eligibility.Engine
+	products   products.Service
+	bureau     creditbureau.CreditBureau
+	experiment *eligibility.Experiment
+	notifier   notifications.Notifier
+
+	nationalIds       *nationalid.Protector
+	requireNationalId bool
 }
 
-func NewService(repository Repository) Service {
+// NewService builds the create service. bureau may be nil, in which case no
+// credit report is fetched and the credit score rule passes. experiment may
+// be nil when no challenger ruleset is being trialled. nationalIds may only
+// be nil when no request carries a national ID. notifier may be nil, in
+// which case applicants are not told of decisions.
+func NewService(repository Repository, transactor database.Transactor, engine *eligibility.Engine, products products.Service, bureau creditbureau.CreditBureau, experiment *eligibility.Experiment, nationalIds *nationalid.Protector, requireNationalId bool, notifier notifications.Notifier) Service {
 	return &ServiceImopl{
-		repository: repository,
+		repository:        repository,
+		transactor:        transactor,
+		engine:            engine,
+		products:          products,
+		bureau:            bureau,
+		experiment:        experiment,
+		nationalIds:       nationalIds,
+		requireNationalId: requireNationalId,
+		notifier:          notifier,
 	}
 }
 
-func (s *ServiceImopl) CreateLoanApplication(ctx context.Context, req HttpRequest) (string, string, error) {
+// CreateLoanApplication evaluates and stores the application whether or not
+// it is eligible. The returned error is only set when nothing was stored.
+func (s *ServiceImopl) CreateLoanApplication(ctx context.Context, req HttpRequest) (HttpResponse, error) {
 
 	applicationId := uuid.New().String()
 	timestamp := time.Now()
-	timestampString := timestamp.Format(time.RFC3339)
 
-	// Eligibility checks
-	if req.MonthlyIncome < 10000 {
-		return applicationId, timestampString, errors.New(ErrMonthlyIncomeInsufficient)
+	d, err := s.decide(ctx, applicationId, req, timestamp)
+	if err != nil {
+		return HttpResponse{}, err
+	}
+	d.application.Version = 1
+
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repository.CreateLoanApplication(ctx, d.application); err != nil {
+			return err
+		}
+		return s.saveDecision(ctx, d)
+	})
+	if err != nil {
+		return HttpResponse{}, err
+	}
+
+	return d.response(), nil
+}
+
+// CreateDraft stores the request as a draft without evaluating it. Fields
+// may be left empty until the draft is submitted.
+func (s *ServiceImopl) CreateDraft(ctx context.Context, req HttpRequest) (DraftResponse, error) {
+
+	application, err := s.newLoanApplication(uuid.New().String(), req, time.Now())
+	if err != nil {
+		return DraftResponse{}, err
+	}
+	application.Status = store.ApplicationDraft
+	application.Version = 1
+	coApplicants, err := s.newLoanCoApplicants(application.ApplicationId, req)
+	if err != nil {
+		return DraftResponse{}, err
+	}
+
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repository.CreateLoanApplication(ctx, application); err != nil {
+			return err
+		}
+		if err := s.repository.CreateLoanObligations(ctx, newLoanObligations(application.ApplicationId, req)); err != nil {
+			return err
+		}
+		return s.createLoanCoApplicants(ctx, coApplicants)
+	})
+	if err != nil {
+		return DraftResponse{}, err
+	}
+
+	return newDraftResponse(application, req), nil
+}
+
+// UpdateDraft merges patch, a partial HttpRequest, into the draft stored at
+// version. A list of obligations or co-applicants in patch replaces the
+// stored one.
+func (s *ServiceImopl) UpdateDraft(ctx context.Context, applicationId string, version int, patch []byte) (DraftResponse, error) {
+
+	var res DraftResponse
+	err := s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		draft, err := s.repository.GetLoanApplicationForUpdate(ctx, applicationId)
+		if err != nil {
+			return err
+		}
+		if err := checkDraft(draft, version); err != nil {
+			return err
+		}
+		req, err := s.draftRequest(ctx, draft)
+		if err != nil {
+			return err
+		}
+		if req, err = mergeDraft(req, patch); err != nil {
+			return err
+		}
+
+		application, err := s.newLoanApplication(applicationId, req, draft.Timestamp)
+		if err != nil {
+			return err
+		}
+		application.Status = store.ApplicationDraft
+		application.Version = draft.Version + 1
+		coApplicants, err := s.newLoanCoApplicants(applicationId, req)
+		if err != nil {
+			return err
+		}
+		if err := s.repository.UpdateLoanApplication(ctx, application); err != nil {
+			return err
+		}
+		if err := s.repository.DeleteLoanObligations(ctx, applicationId); err != nil {
+			return err
+		}
+		if err := s.repository.CreateLoanObligations(ctx, newLoanObligations(applicationId, req)); err != nil {
+			return err
+		}
+		if err := s.repository.DeleteLoanCoApplicants(ctx, applicationId); err != nil {
+			return err
+		}
+		if err := s.createLoanCoApplicants(ctx, coApplicants); err != nil {
+			return err
+		}
+
+		res = newDraftResponse(application, req)
+		return nil
+	})
+	if err != nil {
+		return DraftResponse{}, err
+	}
+
+	return res, nil
+}
+
+// SubmitDraft evaluates the draft stored at version exactly like
+// CreateLoanApplication and stores the decision in place of the draft. The
+// application's timestamp becomes the time of submission.
+func (s *ServiceImopl) SubmitDraft(ctx context.Context, applicationId string, version int) (HttpResponse, error) {
+
+	draft, err := s.repository.GetLoanApplication(ctx, applicationId)
+	if err != nil {
+		return HttpResponse{}, err
+	}
+	if err := checkDraft(draft, version); err != nil {
+		return HttpResponse{}, err
+	}
+	req, err := s.draftRequest(ctx, draft)
+	if err != nil {
+		return HttpResponse{}, err
+	}
+	if err := validateRequest(req); err != nil {
+		return HttpResponse{}, asValidationError(err)
+	}
+
+	// The bureau is called before the row is locked; the version is checked
+	// again below so an edit made in the meantime is not overwritten.
+	d, err := s.decide(ctx, applicationId, req, time.Now())
+	if err != nil {
+		return HttpResponse{}, err
+	}
+
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		locked, err := s.repository.GetLoanApplicationForUpdate(ctx, applicationId)
+		if err != nil {
+			return err
+		}
+		if err := checkDraft(locked, version); err != nil {
+			return err
+		}
+
+		d.application.Version = locked.Version + 1
+		if err := s.repository.UpdateLoanApplication(ctx, d.application); err != nil {
+			return err
+		}
+		if err := s.repository.DeleteLoanObligations(ctx, applicationId); err != nil {
+			return err
+		}
+		if err := s.repository.DeleteLoanCoApplicants(ctx, applicationId); err != nil {
+			return err
+		}
+		return s.saveDecision(ctx, d)
+	})
+	if err != nil {
+		return HttpResponse{}, err
+	}
+
+	return d.response(), nil
+}
+
+// decided is an evaluated application and the records stored with it.
+type decided struct {
+	evaluation
+	application  LoanApplicationEntity
+	obligations  []LoanObligationEntity
+	coApplicants []LoanCoApplicantEntity
+	experiment   *LoanExperimentEntity
+}
+
+// checkNationalIdRequired rejects a request without national IDs when
+// national_id.required is set. Submitting and simulating both apply it, so a
+// simulation never approves what a submission would reject.
+func (s *ServiceImopl) checkNationalIdRequired(req HttpRequest) error {
+	if !s.requireNationalId {
+		return nil
+	}
+	missing := []string{}
+	if req.NationalId == "" {
+		missing = append(missing, "nationalId")
+	}
+	for i, v := range req.CoApplicants {
+		if v.NationalId == "" {
+			missing = append(missing, fmt.Sprintf("coApplicants[%d].nationalId", i))
+		}
+	}
+	if len(missing) > 0 {
+		return ValidationError{Reason: "missing required fields: " + strings.Join(missing, ", ")}
+	}
+	return nil
+}
+
+// decide evaluates req as applicationId at the given time, fetching a credit
+// report and running the experiment's challenger. Nothing is stored.
+func (s *ServiceImopl) decide(ctx context.Context, applicationId string, req HttpRequest, at time.Time) (decided, error) {
+
+	if err := s.checkNationalIdRequired(req); err != nil {
+		return decided{}, err
 	}
 
-	if req.Age < 20 || req.Age > 60 {
-		return applicationId, timestampString, errors.New(ErrAgeNotInRange)
+	product, termMonths, err := s.resolveProduct(ctx, req, at)
+	if err != nil {
+		return decided{}, err
 	}
 
-	if req.LoanPurpose == "business" {
-		return applicationId, timestampString, errors.New(ErrBusinessLoansNotSupported)
+	eval, err := s.evaluate(req, product, termMonths, s.fetchCreditReport(ctx, applicationId, req, at), at)
+	if err != nil {
+		return decided{}, err
 	}
+	decision := eval.decision
 
-	if req.LoanAmount > 12*req.MonthlyIncome {
-		return applicationId, timestampString, errors.New(ErrLoanAmountExceedsCap)
+	decisionJSON, err := json.Marshal(decision)
+	if err != nil {
+		return decided{}, err
 	}
 
-	LoanApplicationInsert := LoanApplicationEntity{
+	application, err := s.newLoanApplication(applicationId, req, at)
+	if err != nil {
+		return decided{}, err
+	}
+	coApplicants, err := s.newLoanCoApplicants(applicationId, req)
+	if err != nil {
+		return decided{}, err
+	}
+	application.Status = store.ApplicationSubmitted
+	application.TermMonths = termMonths
+	application.InterestRateBps = eval.applicant.AnnualRateBps
+	application.Eligible = sql.NullBool{Bool: decision.Eligible, Valid: true}
+	application.Reason = sql.NullString{String: decision.Reason, Valid: true}
+	application.Decision = types.NullJSONText{JSONText: decisionJSON, Valid: true}
+	application.Outcome = sql.NullString{String: decision.Outcome, Valid: true}
+	if decision.Scorecard != nil {
+		application.RiskScore = sql.NullInt32{Int32: int32(decision.Scorecard.Score), Valid: true}
+		application.RiskGrade = sql.NullString{String: decision.Scorecard.Grade, Valid: true}
+	}
+	if eval.offer != nil {
+		offerJSON, err := json.Marshal(eval.offer)
+		if err != nil {
+			return decided{}, err
+		}
+		application.Offer = types.NullJSONText{JSONText: offerJSON, Valid: true}
+	}
+
+	return decided{
+		evaluation:   eval,
+		application:  application,
+		obligations:  newLoanObligations(applicationId, req),
+		coApplicants: coApplicants,
+		experiment:   s.challenge(applicationId, eval.applicant, product, at),
+	}, nil
+}
+
+// saveDecision stores everything that belongs to a decided application
+// except the application row itself, which it expects to be written
+// already, and tells the applicant of the decision.
+func (s *ServiceImopl) saveDecision(ctx context.Context, d decided) error {
+
+	applicationId := d.application.ApplicationId
+	if err := s.repository.CreateLoanObligations(ctx, d.obligations); err != nil {
+		return err
+	}
+	if err := s.createLoanCoApplicants(ctx, d.coApplicants); err != nil {
+		return err
+	}
+	if d.applicant.CreditReport != nil {
+		if err := s.repository.CreateCreditReport(ctx, store.NewCreditReport(applicationId, *d.applicant.CreditReport)); err != nil {
+			return err
+		}
+	}
+	if d.experiment != nil {
+		if err := s.repository.CreateLoanExperiment(ctx, *d.experiment); err != nil {
+			return err
+		}
+	}
+	if s.notifier != nil {
+		if err := s.notifier.Notify(ctx, applicationId, notifications.EventForOutcome(d.decision.Outcome)); err != nil {
+			return err
+		}
+	}
+	if d.decision.Outcome != scorecard.OutcomeRefer {
+		return nil
+	}
+	return s.repository.CreateUnderwritingItem(ctx, UnderwritingItemEntity{
 		ApplicationId: applicationId,
-		FullName:      req.FullName,
+		Status:        store.UnderwritingPending,
+		CreatedAt:     d.application.Timestamp,
+	})
+}
+
+func (d decided) response() HttpResponse {
+	return HttpResponse{
+		ApplicationId: d.application.ApplicationId,
+		Eligible:      d.decision.Eligible,
+		Outcome:       d.decision.Outcome,
+		Reason:        d.decision.Reason,
+		Timestamp:     d.application.Timestamp.Format(time.RFC3339),
+		Quote:         &d.quote,
+		Checks:        d.decision.Checks,
+		Offer:         d.offer,
+	}
+}
+
+// draftRequest rebuilds the request a draft was saved from.
+func (s *ServiceImopl) draftRequest(ctx context.Context, draft LoanApplicationEntity) (HttpRequest, error) {
+
+	obligations, err := s.repository.GetLoanObligations(ctx, draft.ApplicationId)
+	if err != nil {
+		return HttpRequest{}, err
+	}
+
+	coApplicants, err := s.repository.GetLoanCoApplicants(ctx, draft.ApplicationId)
+	if err != nil {
+		return HttpRequest{}, err
+	}
+
+	req := HttpRequest{
+		PersonalDetails: PersonalDetails{
+			FullName:     draft.FullName,
+			GivenNameTh:  draft.GivenNameTh,
+			FamilyNameTh: draft.FamilyNameTh,
+			GivenNameEn:  draft.GivenNameEn,
+			FamilyNameEn: draft.FamilyNameEn,
+			PhoneNumber:  draft.PhoneNumber,
+			Email:        draft.Email,
+		},
+		MonthlyIncome: draft.Income(),
+		LoanAmount:    draft.Loan(),
+		LoanPurpose:   draft.LoanPurpose,
+		TermMonths:    draft.TermMonths,
+		Locale:        draft.Locale,
+	}
+	if draft.EmploymentType != "" || draft.EmployerName != "" || draft.EmploymentTenureMonths != 0 || draft.IncomeFrequency != "" {
+		req.Employment = &Employment{
+			Type:            draft.EmploymentType,
+			EmployerName:    draft.EmployerName,
+			TenureMonths:    draft.EmploymentTenureMonths,
+			IncomeFrequency: draft.IncomeFrequency,
+		}
+	}
+	if draft.DateOfBirth.Valid {
+		req.DateOfBirth = draft.DateOfBirth.Time.Format(birthdate.Layout)
+	}
+	if draft.NationalIdEncrypted != nil {
+		if req.NationalId, err = s.openNationalId(draft.NationalIdEncrypted); err != nil {
+			return HttpRequest{}, err
+		}
+	}
+	for _, v := range obligations {
+		req.ExistingObligations = append(req.ExistingObligations, Obligation{
+			Type:           v.ObligationType,
+			MonthlyPayment: v.Payment(),
+		})
+	}
+	for _, v := range coApplicants {
+		coApplicant := CoApplicant{
+			Role: v.Role,
+			PersonalDetails: PersonalDetails{
+				FullName:     v.FullName,
+				GivenNameTh:  v.GivenNameTh,
+				FamilyNameTh: v.FamilyNameTh,
+				GivenNameEn:  v.GivenNameEn,
+				FamilyNameEn: v.FamilyNameEn,
+				PhoneNumber:  v.PhoneNumber,
+				Email:        v.Email,
+			},
+			MonthlyIncome: v.Income(),
+		}
+		if v.DateOfBirth.Valid {
+			coApplicant.DateOfBirth = v.DateOfBirth.Time.Format(birthdate.Layout)
+		}
+		if v.NationalIdEncrypted != nil {
+			if coApplicant.NationalId, err = s.openNationalId(v.NationalIdEncrypted); err != nil {
+				return HttpRequest{}, err
+			}
+		}
+		req.CoApplicants = append(req.CoApplicants, coApplicant)
+	}
+	return req, nil
+}
+
+// mergeDraft applies the fields present in patch to req and validates the
+// result the same way a new draft is validated.
+func mergeDraft(req HttpRequest, patch []byte) (HttpRequest, error) {
+
+	// Decoding into a fresh slice keeps stored obligations from leaking
+	// into a shorter replacement list.
+	merged := req
+	merged.ExistingObligations = nil
+	merged.CoApplicants = nil
+	if err := json.Unmarshal(patch, &merged); err != nil {
+		return HttpRequest{}, ValidationError{Reason: err.Error()}
+	}
+	if merged.ExistingObligations == nil {
+		merged.ExistingObligations = req.ExistingObligations
+	}
+	if merged.CoApplicants == nil {
+		merged.CoApplicants = req.CoApplicants
+	}
+	merged = normalizeRequest(merged)
+
+	if err := checkValueCondition(merged); err != nil {
+		return HttpRequest{}, asValidationError(err)
+	}
+	return merged, nil
+}
+
+func checkDraft(application LoanApplicationEntity, version int) error {
+	if application.Status != store.ApplicationDraft {
+		return ErrNotDraft
+	}
+	if application.Version != version {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// newLoanApplication maps the request fields of an application. Amounts left
+// empty in a draft are stored in the default currency, the age column keeps
+// the age on the given date for reporting, the phone number is
+// stored both as written and in E.164, and the national ID is only stored
+// sealed.
+func (s *ServiceImopl) newLoanApplication(applicationId string, req HttpRequest, at time.Time) (LoanApplicationEntity, error) {
+	application := LoanApplicationEntity{
+		ApplicationId:         applicationId,
+		FullName:              req.FullName,
+		GivenNameTh:           req.GivenNameTh,
+		FamilyNameTh:          req.FamilyNameTh,
+		GivenNameEn:           req.GivenNameEn,
+		FamilyNameEn:          req.FamilyNameEn,
+		FullNameKey:           personname.MatchKey(req.FullName),
+		MonthlyIncome:         req.MonthlyIncome.Amount,
+		MonthlyIncomeCurrency: currencyOrDefault(req.MonthlyIncome),
+		LoanAmount:            req.LoanAmount.Amount,
+		LoanAmountCurrency:    currencyOrDefault(req.LoanAmount),
+		LoanPurpose:           req.LoanPurpose,
+		TermMonths:            req.TermMonths,
+		PhoneNumber:           req.PhoneNumber,
+		Email:                 req.Email,
+		Locale:                req.Locale,
+		Timestamp:             at,
+	}
+	if e := req.Employment; e != nil {
+		application.EmploymentType = e.Type
+		application.EmployerName = e.EmployerName
+		application.EmploymentTenureMonths = e.TenureMonths
+		application.IncomeFrequency = e.IncomeFrequency
+	}
+	if req.DateOfBirth != "" {
+		dob, err := birthdate.Parse(req.DateOfBirth)
+		if err != nil {
+			return LoanApplicationEntity{}, ValidationError{Reason: err.Error()}
+		}
+		application.DateOfBirth = sql.NullTime{Time: dob, Valid: true}
+		application.Age = birthdate.AgeAt(dob, at)
+	}
+	if req.PhoneNumber != "" {
+		number, err := phone.Parse(req.PhoneNumber)
+		if err != nil {
+			return LoanApplicationEntity{}, ValidationError{Reason: err.Error()}
+		}
+		application.PhoneE164 = number.E164
+		application.PhoneRegion = number.Region
+		application.PhoneType = string(number.Type)
+	}
+	var err error
+	if application.NationalIdEncrypted, application.NationalIdHash, err = s.sealNationalId(req.NationalId); err != nil {
+		return LoanApplicationEntity{}, err
+	}
+	return application, nil
+}
+
+// newLoanCoApplicants maps co-applicants the way newLoanApplication maps the
+// applicant.
+func (s *ServiceImopl) newLoanCoApplicants(applicationId string, req HttpRequest) ([]LoanCoApplicantEntity, error) {
+	coApplicants := []LoanCoApplicantEntity{}
+	for _, v := range req.CoApplicants {
+		coApplicant := LoanCoApplicantEntity{
+			ApplicationId:         applicationId,
+			Role:                  v.Role,
+			FullName:              v.FullName,
+			GivenNameTh:           v.GivenNameTh,
+			FamilyNameTh:          v.FamilyNameTh,
+			GivenNameEn:           v.GivenNameEn,
+			FamilyNameEn:          v.FamilyNameEn,
+			FullNameKey:           personname.MatchKey(v.FullName),
+			PhoneNumber:           v.PhoneNumber,
+			Email:                 v.Email,
+			MonthlyIncome:         v.MonthlyIncome.Amount,
+			MonthlyIncomeCurrency: currencyOrDefault(v.MonthlyIncome),
+		}
+		if v.DateOfBirth != "" {
+			dob, err := birthdate.Parse(v.DateOfBirth)
+			if err != nil {
+				return nil, ValidationError{Reason: err.Error()}
+			}
+			coApplicant.DateOfBirth = sql.NullTime{Time: dob, Valid: true}
+		}
+		if v.PhoneNumber != "" {
+			number, err := phone.Parse(v.PhoneNumber)
+			if err != nil {
+				return nil, ValidationError{Reason: err.Error()}
+			}
+			coApplicant.PhoneE164 = number.E164
+			coApplicant.PhoneRegion = number.Region
+			coApplicant.PhoneType = string(number.Type)
+		}
+		var err error
+		if coApplicant.NationalIdEncrypted, coApplicant.NationalIdHash, err = s.sealNationalId(v.NationalId); err != nil {
+			return nil, err
+		}
+		coApplicants = append(coApplicants, coApplicant)
+	}
+	return coApplicants, nil
+}
+
+// createLoanCoApplicants skips the insert for the usual application without
+// co-applicants.
+func (s *ServiceImopl) createLoanCoApplicants(ctx context.Context, coApplicants []LoanCoApplicantEntity) error {
+	if len(coApplicants) == 0 {
+		return nil
+	}
+	return s.repository.CreateLoanCoApplicants(ctx, coApplicants)
+}
+
+var (
+	// errNationalIdsNotAccepted rejects a national ID sent to a deployment
+	// that has no protector configured to store it.
+	errNationalIdsNotAccepted = ValidationError{Reason: "National ID is not accepted by this service"}
+	errNationalIdsDisabled    = errors.New("national ID stored but no protector is configured")
+)
+
+// sealNationalId returns the encrypted ID and its lookup hash, or nothing
+// when id is empty.
+func (s *ServiceImopl) sealNationalId(id string) ([]byte, sql.NullString, error) {
+	if id == "" {
+		return nil, sql.NullString{}, nil
+	}
+	if s.nationalIds == nil {
+		return nil, sql.NullString{}, errNationalIdsNotAccepted
+	}
+
+	sealed, err := s.nationalIds.Seal(id)
+	if err != nil {
+		return nil, sql.NullString{}, err
+	}
+	return sealed, sql.NullString{String: s.nationalIds.Index(id), Valid: true}, nil
+}
+
+func (s *ServiceImopl) openNationalId(sealed []byte) (string, error) {
+	if s.nationalIds == nil {
+		return "", errNationalIdsDisabled
+	}
+	return s.nationalIds.Open(sealed)
+}
+
+func newLoanObligations(applicationId string, req HttpRequest) []LoanObligationEntity {
+	obligations := []LoanObligationEntity{}
+	for _, v := range req.ExistingObligations {
+		obligations = append(obligations, LoanObligationEntity{
+			ApplicationId:  applicationId,
+			ObligationType: v.Type,
+			MonthlyPayment: v.MonthlyPayment.Amount,
+			Currency:       v.MonthlyPayment.Currency,
+		})
+	}
+	return obligations
+}
+
+// newDraftResponse echoes the draft with national IDs masked; they are kept
+// on the draft and do not need to be sent again.
+func newDraftResponse(application LoanApplicationEntity, req HttpRequest) DraftResponse {
+	req.MonthlyIncome = application.Income()
+	req.LoanAmount = application.Loan()
+	req.NationalId = nationalid.Mask(req.NationalId)
+	if req.CoApplicants != nil {
+		coApplicants := make([]CoApplicant, 0, len(req.CoApplicants))
+		for _, v := range req.CoApplicants {
+			v.MonthlyIncome = money.New(v.MonthlyIncome.Amount, currencyOrDefault(v.MonthlyIncome))
+			v.NationalId = nationalid.Mask(v.NationalId)
+			coApplicants = append(coApplicants, v)
+		}
+		req.CoApplicants = coApplicants
+	}
+	return DraftResponse{
+		ApplicationId: application.ApplicationId,
+		Status:        application.Status,
+		Version:       application.Version,
+		HttpRequest:   req,
+	}
+}
+
+func currencyOrDefault(m money.Money) string {
+	if m.Currency == "" {
+		return money.DefaultCurrency
+	}
+	return m.Currency
+}
+
+// SimulateLoanApplication runs the same evaluation as CreateLoanApplication
+// without storing anything. The credit bureau is not consulted, so the
+// credit score rule passes and the scorecard treats the score as missing.
+func (s *ServiceImopl) SimulateLoanApplication(ctx context.Context, req HttpRequest) (SimulateResponse, error) {
+
+	if err := s.checkNationalIdRequired(req); err != nil {
+		return SimulateResponse{}, err
+	}
+
+	timestamp := time.Now()
+
+	product, termMonths, err := s.resolveProduct(ctx, req, timestamp)
+	if err != nil {
+		return SimulateResponse{}, err
+	}
+
+	eval, err := s.evaluate(req, product, termMonths, nil, timestamp)
+	if err != nil {
+		return SimulateResponse{}, err
+	}
+
+	res := SimulateResponse{
+		Eligible: eval.decision.Eligible,
+		Outcome:  eval.decision.Outcome,
+		Reason:   eval.decision.Reason,
+		Quote:    &eval.quote,
+		Decision: eval.decision,
+	}
+	if eval.offer != nil {
+		res.OfferOptions = eval.offer.Options
+	}
+	return res, nil
+}
+
+// evaluation is everything decided about a request before it is stored.
+type evaluation struct {
+	applicant eligibility.Applicant
+	decision  eligibility.Decision
+	quote     amortization.Schedule
+	offer     *eligibility.Offer
+}
+
+// resolveProduct looks up the active product for the requested purpose and
+// the term to evaluate. Without a requested term it is the product's longest
+// term that ends before the applicant passes the maximum age at maturity.
+func (s *ServiceImopl) resolveProduct(ctx context.Context, req HttpRequest, at time.Time) (products.Product, int, error) {
+
+	product, err := s.products.GetActiveProduct(ctx, req.LoanPurpose, at)
+	if err != nil {
+		if strings.Contains(err.Error(), products.ErrReasonProductNotFound) || strings.Contains(err.Error(), products.ErrReasonProductInactive) {
+			return products.Product{}, 0, s.invalidPurposeError(ctx, at)
+		}
+		return products.Product{}, 0, err
+	}
+
+	termMonths := product.TermOrDefault(req.TermMonths)
+	if req.TermMonths == 0 {
+		if dob, err := birthdate.Parse(req.DateOfBirth); err == nil {
+			applicant := eligibility.Applicant{DateOfBirth: dob}
+			termMonths = max(product.MinTermMonths, s.engine.LongestTerm(applicant, product.RuleOverrides, at, termMonths))
+		}
+	}
+	if err := product.CheckTerm(termMonths); err != nil {
+		return products.Product{}, 0, ValidationError{Reason: err.Error()}
+	}
+	return product, termMonths, nil
+}
+
+func (s *ServiceImopl) evaluate(req HttpRequest, product products.Product, termMonths int, report *creditbureau.Report, at time.Time) (evaluation, error) {
+
+	rateBps := product.QuoteRateBps()
+
+	obligations := []money.Money{}
+	for _, v := range req.ExistingObligations {
+		obligations = append(obligations, v.MonthlyPayment)
+	}
+
+	dob, err := birthdate.Parse(req.DateOfBirth)
+	if err != nil {
+		return evaluation{}, ValidationError{Reason: err.Error()}
+	}
+
+	applicant := eligibility.Applicant{
 		MonthlyIncome: req.MonthlyIncome,
 		LoanAmount:    req.LoanAmount,
 		LoanPurpose:   req.LoanPurpose,
-		Age:           req.Age,
-		PhoneNumber:   req.PhoneNumber,
+		DateOfBirth:   dob,
+		TermMonths:    termMonths,
+		AnnualRateBps: rateBps,
+
+		ExistingObligations: obligations,
+		CreditReport:        report,
+	}
+	if req.Employment != nil {
+		applicant.EmploymentType = req.Employment.Type
+		applicant.TenureMonths = req.Employment.TenureMonths
+	}
+	for _, v := range req.CoApplicants {
+		if v.Role == RoleCoBorrower {
+			applicant.CoBorrowerIncomes = append(applicant.CoBorrowerIncomes, v.MonthlyIncome)
+		}
+	}
+	decision, err := s.engine.Evaluate(applicant, product.RuleOverrides, at)
+	if err != nil {
+		return evaluation{}, ValidationError{Reason: err.Error()}
+	}
+
+	if err := checkAmountRange(decision, product); err != nil {
+		return evaluation{}, err
+	}
+
+	quote, err := amortization.Calculate(req.LoanAmount, rateBps, termMonths)
+	if err != nil {
+		return evaluation{}, ValidationError{Reason: err.Error()}
+	}
+
+	offer, err := s.counterOffer(applicant, product, decision, at)
+	if err != nil {
+		return evaluation{}, err
+	}
+
+	return evaluation{
+		applicant: applicant,
+		decision:  decision,
+		quote:     quote,
+		offer:     offer,
+	}, nil
+}
+
+// challenge assigns the application to an experiment arm and evaluates the
+// challenger ruleset. The result is only recorded, never returned to the
+// applicant, so a failed challenger evaluation is logged and skipped.
+func (s *ServiceImopl) challenge(applicationId string, applicant eligibility.Applicant, product products.Product, at time.Time) *LoanExperimentEntity {
+	if s.experiment == nil {
+		return nil
+	}
+
+	decision, err := s.experiment.Challenger().Evaluate(applicant, product.RuleOverrides, at)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil
+	}
+	decisionJSON, err := json.Marshal(decision)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil
+	}
+
+	return &LoanExperimentEntity{
+		ApplicationId:      applicationId,
+		Experiment:         s.experiment.Name(),
+		Arm:                s.experiment.Assign(applicationId),
+		ChallengerEligible: decision.Eligible,
+		ChallengerOutcome:  decision.Outcome,
+		ChallengerReason:   decision.Reason,
+		ChallengerDecision: decisionJSON,
+		CreatedAt:          at,
+	}
+}
+
+// fetchCreditReport returns nil when no bureau is configured. A bureau that
+// cannot be reached gives an unavailable report instead of an error, so the
+// application is still stored with that reason.
+func (s *ServiceImopl) fetchCreditReport(ctx context.Context, applicationId string, req HttpRequest, at time.Time) *creditbureau.Report {
+	if s.bureau == nil {
+		return nil
+	}
+
+	// Bureaus match on E.164; the request has been validated, so a parse
+	// failure only leaves the number as written.
+	phoneNumber := req.PhoneNumber
+	if number, err := phone.Parse(req.PhoneNumber); err == nil {
+		phoneNumber = number.E164
+	}
+
+	report, err := s.bureau.Fetch(ctx, creditbureau.Inquiry{
+		ApplicationId: applicationId,
+		NationalId:    req.NationalId,
+		FullName:      req.FullName,
+		PhoneNumber:   phoneNumber,
 		Email:         req.Email,
-		Timestamp:     timestamp,
+	})
+	if err != nil {
+		log.Println("err: ", err)
+		report = creditbureau.Unavailable(s.bureau.Name(), at)
 	}
-	if err := s.repository.CreateLoanApplication(ctx, LoanApplicationInsert); err != nil {
-		return applicationId, timestampString, err
+	return &report
+}
+
+// counterOffer returns the options that would make a declined applicant
+// eligible, keeping only amounts the product accepts.
+func (s *ServiceImopl) counterOffer(applicant eligibility.Applicant, product products.Product, decision eligibility.Decision, at time.Time) (*eligibility.Offer, error) {
+
+	offer, err := s.engine.CounterOffer(applicant, product.RuleOverrides, at, decision, product.MaxTermMonths)
+	if err != nil || offer == nil {
+		return nil, err
 	}
 
-	return applicationId, timestampString, nil
+	options := []eligibility.OfferOption{}
+	for _, v := range offer.Options {
+		normalized, _, err := s.engine.Normalize(v.LoanAmount, at)
+		if err != nil {
+			return nil, err
+		}
+		if product.CheckAmount(normalized) == nil {
+			options = append(options, v)
+		}
+	}
+	if len(options) == 0 {
+		return nil, nil
+	}
+
+	offer.Options = options
+	offer.ExpiresAt = at.Add(OfferValidity)
+	return offer, nil
+}
+
+func (s *ServiceImopl) invalidPurposeError(ctx context.Context, at time.Time) error {
+	active, err := s.products.GetActiveProducts(ctx, at)
+	if err != nil {
+		return err
+	}
+
+	codes := []string{}
+	for _, v := range active {
+		codes = append(codes, v.Code)
+	}
+	return ValidationError{Reason: "Loan purpose must be one of: " + strings.Join(codes, ", ")}
+}
+
+func checkAmountRange(decision eligibility.Decision, product products.Product) error {
+	base := decision.BaseCurrency
+	if decision.MonthlyIncome.LessThan(money.FromMajor(MinMonthlyIncome, base)) || decision.MonthlyIncome.GreaterThan(money.FromMajor(MaxMonthlyIncome, base)) {
+		return ValidationError{Reason: "Monthly income must be between 5,000 and 5,000,000"}
+	}
+	if decision.LoanAmount.LessThan(money.FromMajor(MinLoanAmount, base)) || decision.LoanAmount.GreaterThan(money.FromMajor(MaxLoanAmount, base)) {
+		return ValidationError{Reason: "Loan amount must be between 1,000 and 5,000,000"}
+	}
+	if err := product.CheckAmount(decision.LoanAmount); err != nil {
+		return ValidationError{Reason: err.Error()}
+	}
+	return nil
 }
@@ -16,7 +16,27 @@ func NewMockService() *MockService {
 	return &MockService{}
 }
 
-func (m *MockService) CreateLoanApplication(ctx context.Context, req HttpRequest) (string, string, error) {
+func (m *MockService) CreateLoanApplication(ctx context.Context, req HttpRequest) (HttpResponse, error) {
 	args := m.Called(ctx, req)
-	return args.String(0), args.String(1), args.Error(2)
+	return args.Get(0).(HttpResponse), args.Error(1)
+}
+
+func (m *MockService) SimulateLoanApplication(ctx context.Context, req HttpRequest) (SimulateResponse, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(SimulateResponse), args.Error(1)
+}
+
+func (m *MockService) CreateDraft(ctx context.Context, req HttpRequest) (DraftResponse, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(DraftResponse), args.Error(1)
+}
+
+func (m *MockService) UpdateDraft(ctx context.Context, applicationId string, version int, patch []byte) (DraftResponse, error) {
+	args := m.Called(ctx, applicationId, version, patch)
+	return args.Get(0).(DraftResponse), args.Error(1)
+}
+
+func (m *MockService) SubmitDraft(ctx context.Context, applicationId string, version int) (HttpResponse, error) {
+	args := m.Called(ctx, applicationId, version)
+	return args.Get(0).(HttpResponse), args.Error(1)
 }
@@ -32,12 +32,15 @@ func (h *Handler) GetLoanApplicationWithAppId(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
+	// Drafts are edited with If-Match set to this tag.
+	c.Header("ETag", `"`+strconv.Itoa(loanApplication.Version)+`"`)
 	c.JSON(http.StatusOK, loanApplication)
 }
 
 func (h *Handler) GetAllLoanApplication(c *gin.Context) {
 
 	purpose := c.Query("purpose")
+	nationalId := c.GetHeader(HeaderNationalId)
 	limit := c.Query("limit")
 	offset := c.Query("page")
 
@@ -53,7 +56,7 @@ func (h *Handler) GetAllLoanApplication(c *gin.Context) {
 		return
 	}
 
-	loanApplications, totalItems, err := h.service.GetAllLoanApplication(c.Request.Context(), purpose, limitInt, offsetInt)
+	loanApplications, totalItems, err := h.service.GetAllLoanApplication(c.Request.Context(), purpose, nationalId, limitInt, offsetInt)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
@@ -1,11 +1,17 @@
 package loaninquiry
 
 import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/pkg/fx"
+	"backend-loan-pre-approval/pkg/money"
+	"backend-loan-pre-approval/pkg/nationalid"
+	"backend-loan-pre-approval/pkg/store"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -16,7 +22,8 @@ import (
 
 func TestGetLoanApplicationWithAppId(t *testing.T) {
 	repo := NewMockRepo()
-	s := NewService(repo)
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	s := NewService(repo, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), nil)
 	h := NewHandler(s)
 
 	repo.On("GetLoanApplicationWithAppId", mock.Anything, mock.Anything).Return(LoanApplicationEntity{}, errors.New(ErrNoRows))
@@ -44,3 +51,74 @@ func TestGetLoanApplicationWithAppId(t *testing.T) {
 	// Assert
 	assert.Equal(t, messageExpected, response["message"])
 }
+
+func TestGetLoanApplicationWithCoApplicants(t *testing.T) {
+	repo := NewMockRepo()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	nationalIds, _ := nationalid.NewProtector("ZBnuwcjoHClycegcsXwqQtqya7QvoEN4Z0U8Zy7K4/8=", "M3B6V2BzyUluf/YGIVpXhL29p93sfrhGpYpB2refa+8=", false, true)
+	s := NewService(repo, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), nationalIds)
+	h := NewHandler(s)
+
+	applicationId := uuid.New().String()
+	sealed, _ := nationalIds.Seal("1101700156508")
+
+	repo.On("GetLoanApplicationWithAppId", mock.Anything, applicationId).Return(LoanApplicationEntity{
+		ApplicationId: applicationId,
+		FullName:      "Somkanit Jitsanook",
+		Status:        store.ApplicationSubmitted,
+	}, nil)
+	repo.On("GetLoanCoApplicants", mock.Anything, []string{applicationId}).Return([]LoanCoApplicantEntity{{
+		ApplicationId:         applicationId,
+		Role:                  "coBorrower",
+		FullName:              "Malee Jitsanook",
+		NationalIdEncrypted:   sealed,
+		MonthlyIncome:         1500000,
+		MonthlyIncomeCurrency: money.DefaultCurrency,
+	}}, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/loans/:applicationId", h.GetLoanApplicationWithAppId)
+
+	req := httptest.NewRequest(http.MethodGet, "http://0.0.0.0/api/v1/loans/"+applicationId, nil)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response ApplicationResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, 1, len(response.CoApplicants))
+	assert.Equal(t, "coBorrower", response.CoApplicants[0].Role)
+	assert.Equal(t, "XXXXXXXXX6508", response.CoApplicants[0].NationalId)
+	assert.Equal(t, money.FromMajor(15000, money.DefaultCurrency), response.CoApplicants[0].MonthlyIncome)
+	assert.Assert(t, !strings.Contains(resp.Body.String(), "1101700156508"))
+}
+
+func TestGetAllLoanApplicationByNationalIdHeader(t *testing.T) {
+	repo := NewMockRepo()
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+	nationalIds, _ := nationalid.NewProtector("ZBnuwcjoHClycegcsXwqQtqya7QvoEN4Z0U8Zy7K4/8=", "M3B6V2BzyUluf/YGIVpXhL29p93sfrhGpYpB2refa+8=", false, true)
+	s := NewService(repo, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), nationalIds)
+	h := NewHandler(s)
+
+	repo.On("GetAllLoanApplication", mock.Anything, "", nationalIds.Index("1101700156494"), 10, 1).Return([]LoanApplicationEntity{}, 0, nil)
+	repo.On("GetLoanCoApplicants", mock.Anything, []string{}).Return([]LoanCoApplicantEntity{}, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/loans", h.GetAllLoanApplication)
+
+	req := httptest.NewRequest(http.MethodGet, "http://0.0.0.0/api/v1/loans?page=1&limit=10", nil)
+	req.Header.Set(HeaderNationalId, "1-1017-00156-49-4")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	repo.AssertExpectations(t)
+}
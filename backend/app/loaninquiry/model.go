@@ -1,34 +1,109 @@
 package loaninquiry
 
-import "time"
+import (
+	"backend-loan-pre-approval/pkg/money"
+	"time"
+)
 
 // ========= sample response inquiry ========= //
 //
 //	{
 //		"applicationId": "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+//		"nationalId": "XXXXXXXXX6494",
 //		"fullName": "Somkanit Jitsanook",
-//		"monthlyIncome": 5000,
-//		"loanAmount": 10000,
+//		"givenNameEn": "Somkanit",
+//		"familyNameEn": "Jitsanook",
+//		"monthlyIncome": {"amount": "5000.00", "currency": "THB"},
+//		"loanAmount": {"amount": "10000.00", "currency": "THB"},
 //		"loanPurpose": "home",
-//		"age": 25,
-//		"phoneNumber": "0851234567",
+//		"termMonths": 24,
+//		"annualRateBps": 325,
+//		"dateOfBirth": "1999-04-12",
+//		"age": 26,
+//		"phoneNumber": "085-123-4567",
+//		"phoneE164": "+66851234567",
+//		"phoneType": "mobile",
 //		"email": "demo@example.com",
+//		"employment": {
+//			"type": "salaried",
+//			"employerName": "Siam Logistics Co., Ltd.",
+//			"tenureMonths": 38,
+//			"incomeFrequency": "monthly"
+//		},
+//		"coApplicants": [
+//			{
+//				"role": "coBorrower",
+//				"nationalId": "XXXXXXXXX6508",
+//				"fullName": "Malee Jitsanook",
+//				"dateOfBirth": "1997-08-02",
+//				"age": 28,
+//				"phoneNumber": "085-987-6543",
+//				"phoneE164": "+66859876543",
+//				"phoneType": "mobile",
+//				"email": "malee@example.com",
+//				"monthlyIncome": {"amount": "15000.00", "currency": "THB"}
+//			}
+//		],
+//		"status": "submitted",
+//		"version": 1,
 //		"eligible": true,
 //		"reason": "Eligible under base rules",
 //		"timestamp": "2025-07-19T19:34:56+07:00"
 //	}
+//
+// age is the applicant's age today. Applications stored before dates of
+// birth were captured have no dateOfBirth and show the age stated on them.
+// Drafts have no decision yet, so eligible is false and reason is empty.
 type ApplicationResponse struct {
-	ApplicationID string    `json:"applicationId"`
-	FullName      string    `json:"fullName"`
-	MonthlyIncome int       `json:"monthlyIncome"`
-	LoanAmount    int       `json:"loanAmount"`
-	LoanPurpose   string    `json:"loanPurpose"`
-	Age           int       `json:"age"`
-	PhoneNumber   string    `json:"phoneNumber"`
-	Email         string    `json:"email"`
-	Eligible      bool      `json:"eligible"`
-	Reason        string    `json:"reason"`
-	Timestamp     time.Time `json:"timestamp"`
+	ApplicationID string                `json:"applicationId"`
+	NationalId    string                `json:"nationalId,omitempty"`
+	FullName      string                `json:"fullName"`
+	GivenNameTh   string                `json:"givenNameTh,omitempty"`
+	FamilyNameTh  string                `json:"familyNameTh,omitempty"`
+	GivenNameEn   string                `json:"givenNameEn,omitempty"`
+	FamilyNameEn  string                `json:"familyNameEn,omitempty"`
+	MonthlyIncome money.Money           `json:"monthlyIncome"`
+	LoanAmount    money.Money           `json:"loanAmount"`
+	LoanPurpose   string                `json:"loanPurpose"`
+	TermMonths    int                   `json:"termMonths,omitempty"`
+	AnnualRateBps int                   `json:"annualRateBps,omitempty"`
+	DateOfBirth   string                `json:"dateOfBirth,omitempty"`
+	Age           int                   `json:"age"`
+	PhoneNumber   string                `json:"phoneNumber"`
+	PhoneE164     string                `json:"phoneE164,omitempty"`
+	PhoneType     string                `json:"phoneType,omitempty"`
+	Email         string                `json:"email"`
+	Employment    *Employment           `json:"employment,omitempty"`
+	CoApplicants  []CoApplicantResponse `json:"coApplicants"`
+	Status        string                `json:"status"`
+	Version       int                   `json:"version"`
+	Eligible      bool                  `json:"eligible"`
+	Reason        string                `json:"reason"`
+	Timestamp     time.Time             `json:"timestamp"`
+}
+
+type Employment struct {
+	Type            string `json:"type"`
+	EmployerName    string `json:"employerName,omitempty"`
+	TenureMonths    int    `json:"tenureMonths"`
+	IncomeFrequency string `json:"incomeFrequency,omitempty"`
+}
+
+type CoApplicantResponse struct {
+	Role          string      `json:"role"`
+	NationalId    string      `json:"nationalId,omitempty"`
+	FullName      string      `json:"fullName"`
+	GivenNameTh   string      `json:"givenNameTh,omitempty"`
+	FamilyNameTh  string      `json:"familyNameTh,omitempty"`
+	GivenNameEn   string      `json:"givenNameEn,omitempty"`
+	FamilyNameEn  string      `json:"familyNameEn,omitempty"`
+	DateOfBirth   string      `json:"dateOfBirth,omitempty"`
+	Age           int         `json:"age"`
+	PhoneNumber   string      `json:"phoneNumber"`
+	PhoneE164     string      `json:"phoneE164,omitempty"`
+	PhoneType     string      `json:"phoneType,omitempty"`
+	Email         string      `json:"email"`
+	MonthlyIncome money.Money `json:"monthlyIncome"`
 }
 
 type GetAllLoanApplicationResponse struct {
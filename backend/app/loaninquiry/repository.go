@@ -1,60 +1,68 @@
 package loaninquiry
 
 import (
+	"backend-loan-pre-approval/pkg/store"
 	"context"
+	"database/sql"
 	"errors"
 	"log"
-	"strings"
 
 	"github.com/jmoiron/sqlx"
 )
 
 type Repository interface {
 	GetLoanApplicationWithAppId(ctx context.Context, applicationId string) (LoanApplicationEntity, error)
-	GetAllLoanApplication(ctx context.Context, purpose string, limit int, offset int) ([]LoanApplicationEntity, int, error)
+	GetAllLoanApplication(ctx context.Context, purpose string, nationalIdHash string, limit int, offset int) ([]LoanApplicationEntity, int, error)
+	GetLoanCoApplicants(ctx context.Context, applicationIds []string) ([]LoanCoApplicantEntity, error)
 }
 
 type RepositoryImpl struct {
-	db *sqlx.DB
+	queries *store.Queries
 }
 
 func NewRepository(db *sqlx.DB) Repository {
 	return &RepositoryImpl{
-		db: db,
+		queries: store.New(db),
 	}
 }
 
 func (r *RepositoryImpl) GetLoanApplicationWithAppId(ctx context.Context, applicationId string) (LoanApplicationEntity, error) {
 
-	sql := `SELECT * FROM loan_applications WHERE application_id = $1`
-
-	var loanApplication LoanApplicationEntity
-	if err := r.db.GetContext(ctx, &loanApplication, sql, applicationId); err != nil {
-		if strings.Contains(err.Error(), ErrNoRows) {
+	loanApplication, err := r.queries.GetLoanApplication(ctx, applicationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
 			return LoanApplicationEntity{}, errors.New(ErrNoRows)
 		}
-		finalQuery := r.db.Rebind(sql)
-		log.Println("sql: ", finalQuery)
+		log.Println("err: ", err)
 		return LoanApplicationEntity{}, err
 	}
 
 	return loanApplication, nil
 }
 
-func (r *RepositoryImpl) GetAllLoanApplication(ctx context.Context, purpose string, limit int, offset int) ([]LoanApplicationEntity, int, error) {
-
-	var loanApplications []LoanApplicationEntity
-	args := []interface{}{purpose, limit, offset}
+func (r *RepositoryImpl) GetAllLoanApplication(ctx context.Context, purpose string, nationalIdHash string, limit int, offset int) ([]LoanApplicationEntity, int, error) {
 
-	sql := `SELECT *, COUNT(*) OVER() as total_count FROM loan_applications WHERE ($1 = '' OR loan_purpose = $1) LIMIT $2 OFFSET $3`
-	if err := r.db.SelectContext(ctx, &loanApplications, sql, args...); err != nil {
+	loanApplications, total, err := r.queries.ListLoanApplications(ctx, store.ListLoanApplicationsParams{
+		Purpose:        purpose,
+		NationalIdHash: nationalIdHash,
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		log.Println("err: ", err)
 		return nil, 0, err
 	}
 
-	total := 0
-	if len(loanApplications) > 0 {
-		total = loanApplications[0].TotalCount
+	return loanApplications, total, nil
+}
+
+func (r *RepositoryImpl) GetLoanCoApplicants(ctx context.Context, applicationIds []string) ([]LoanCoApplicantEntity, error) {
+
+	coApplicants, err := r.queries.ListLoanCoApplicantsIn(ctx, applicationIds)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
 	}
 
-	return loanApplications, total, nil
+	return coApplicants, nil
 }
@@ -21,7 +21,12 @@ func (m *MockRepo) GetLoanApplicationWithAppId(ctx context.Context, applicationI
 	return args.Get(0).(LoanApplicationEntity), args.Error(1)
 }
 
-func (m *MockRepo) GetAllLoanApplication(ctx context.Context, purpose string, limit int, offset int) ([]LoanApplicationEntity, int, error) {
-	args := m.Called(ctx, purpose, limit, offset)
+func (m *MockRepo) GetAllLoanApplication(ctx context.Context, purpose string, nationalIdHash string, limit int, offset int) ([]LoanApplicationEntity, int, error) {
+	args := m.Called(ctx, purpose, nationalIdHash, limit, offset)
 	return args.Get(0).([]LoanApplicationEntity), args.Get(1).(int), args.Error(2)
 }
+
+func (m *MockRepo) GetLoanCoApplicants(ctx context.Context, applicationIds []string) ([]LoanCoApplicantEntity, error) {
+	args := m.Called(ctx, applicationIds)
+	return args.Get(0).([]LoanCoApplicantEntity), args.Error(1)
+}
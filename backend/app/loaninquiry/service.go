@@ -1,47 +1,79 @@
 package loaninquiry
 
 import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/pkg/birthdate"
+	"backend-loan-pre-approval/pkg/nationalid"
+	"backend-loan-pre-approval/pkg/store"
 	"context"
 	"errors"
+	"log"
 	"strings"
+	"time"
 )
 
 type Service interface {
 	GetLoanApplicationWithAppId(ctx context.Context, applicationId string) (ApplicationResponse, error)
-	GetAllLoanApplication(ctx context.Context, purpose string, limit int, offset int) ([]ApplicationResponse, int, error)
+	GetAllLoanApplication(ctx context.Context, purpose string, nationalId string, limit int, offset int) ([]ApplicationResponse, int, error)
 }
 
 type ServiceImpl struct {
-	repository Repository
+	repository  Repository
+	engine      *eligibility.Engine
+	nationalIds *nationalid.Protector
 }
 
-func NewService(repository Repository) Service {
+// NewService builds the inquiry service. nationalIds may be nil, in which
+// case national IDs are neither shown nor searchable.
+func NewService(repository Repository, engine *eligibility.Engine, nationalIds *nationalid.Protector) Service {
 	return &ServiceImpl{
-		repository: repository,
+		repository:  repository,
+		engine:      engine,
+		nationalIds: nationalIds,
 	}
 }
 
 func (s *ServiceImpl) GetLoanApplicationWithAppId(ctx context.Context, applicationId string) (ApplicationResponse, error) {
 
 	result, err := s.repository.GetLoanApplicationWithAppId(ctx, applicationId)
-	if strings.Contains(err.Error(), ErrNoRows) {
-		return ApplicationResponse{}, errors.New(ErrReasonApplicationNotFound + applicationId)
+	if err != nil {
+		if strings.Contains(err.Error(), ErrNoRows) {
+			return ApplicationResponse{}, errors.New(ErrReasonApplicationNotFound + applicationId)
+		}
+		return ApplicationResponse{}, err
 	}
+
+	coApplicants, err := s.repository.GetLoanCoApplicants(ctx, []string{result.ApplicationId})
 	if err != nil {
 		return ApplicationResponse{}, err
 	}
 
-	eligible, reason := checkEligibility(result)
+	eligible, reason := s.checkEligibility(result)
+	now := time.Now()
 
 	res := ApplicationResponse{
 		ApplicationID: result.ApplicationId,
+		NationalId:    s.maskedNationalId(result.NationalIdEncrypted),
 		FullName:      result.FullName,
-		MonthlyIncome: result.MonthlyIncome,
-		LoanAmount:    result.LoanAmount,
+		GivenNameTh:   result.GivenNameTh,
+		FamilyNameTh:  result.FamilyNameTh,
+		GivenNameEn:   result.GivenNameEn,
+		FamilyNameEn:  result.FamilyNameEn,
+		MonthlyIncome: result.Income(),
+		LoanAmount:    result.Loan(),
 		LoanPurpose:   result.LoanPurpose,
-		Age:           result.Age,
+		TermMonths:    result.TermMonths,
+		AnnualRateBps: result.InterestRateBps,
+		DateOfBirth:   dateOfBirth(result),
+		Age:           currentAge(result, now),
 		PhoneNumber:   result.PhoneNumber,
+		PhoneE164:     result.PhoneE164,
+		PhoneType:     result.PhoneType,
 		Email:         result.Email,
+		Employment:    employment(result),
+		CoApplicants:  s.coApplicants(coApplicants, now),
+		Status:        result.Status,
+		Version:       result.Version,
 		Eligible:      eligible,
 		Reason:        reason,
 		Timestamp:     result.Timestamp,
@@ -50,25 +82,62 @@ func (s *ServiceImpl) GetLoanApplicationWithAppId(ctx context.Context, applicati
 	return res, nil
 }
 
-func (s *ServiceImpl) GetAllLoanApplication(ctx context.Context, purpose string, limit int, offset int) ([]ApplicationResponse, int, error) {
+func (s *ServiceImpl) GetAllLoanApplication(ctx context.Context, purpose string, nationalId string, limit int, offset int) ([]ApplicationResponse, int, error) {
+
+	// A national ID is matched through its keyed hash, never in clear.
+	nationalIdHash := ""
+	if nationalId != "" {
+		if s.nationalIds == nil {
+			return []ApplicationResponse{}, 0, nil
+		}
+		nationalIdHash = s.nationalIds.Index(nationalid.Normalize(nationalId))
+	}
 
-	result, totalItems, err := s.repository.GetAllLoanApplication(ctx, purpose, limit, offset)
+	result, totalItems, err := s.repository.GetAllLoanApplication(ctx, purpose, nationalIdHash, limit, offset)
 	if err != nil {
 		return []ApplicationResponse{}, 0, err
 	}
 
+	applicationIds := []string{}
+	for _, v := range result {
+		applicationIds = append(applicationIds, v.ApplicationId)
+	}
+	coApplicants, err := s.repository.GetLoanCoApplicants(ctx, applicationIds)
+	if err != nil {
+		return []ApplicationResponse{}, 0, err
+	}
+	byApplication := map[string][]LoanCoApplicantEntity{}
+	for _, v := range coApplicants {
+		byApplication[v.ApplicationId] = append(byApplication[v.ApplicationId], v)
+	}
+
+	now := time.Now()
 	res := []ApplicationResponse{}
 	for _, v := range result {
-		eligible, reason := checkEligibility(v)
+		eligible, reason := s.checkEligibility(v)
 		res = append(res, ApplicationResponse{
 			ApplicationID: v.ApplicationId,
+			NationalId:    s.maskedNationalId(v.NationalIdEncrypted),
 			FullName:      v.FullName,
-			MonthlyIncome: v.MonthlyIncome,
-			LoanAmount:    v.LoanAmount,
+			GivenNameTh:   v.GivenNameTh,
+			FamilyNameTh:  v.FamilyNameTh,
+			GivenNameEn:   v.GivenNameEn,
+			FamilyNameEn:  v.FamilyNameEn,
+			MonthlyIncome: v.Income(),
+			LoanAmount:    v.Loan(),
 			LoanPurpose:   v.LoanPurpose,
-			Age:           v.Age,
+			TermMonths:    v.TermMonths,
+			AnnualRateBps: v.InterestRateBps,
+			DateOfBirth:   dateOfBirth(v),
+			Age:           currentAge(v, now),
 			PhoneNumber:   v.PhoneNumber,
+			PhoneE164:     v.PhoneE164,
+			PhoneType:     v.PhoneType,
 			Email:         v.Email,
+			Employment:    employment(v),
+			CoApplicants:  s.coApplicants(byApplication[v.ApplicationId], now),
+			Status:        v.Status,
+			Version:       v.Version,
 			Eligible:      eligible,
 			Reason:        reason,
 			Timestamp:     v.Timestamp,
@@ -78,18 +147,98 @@ func (s *ServiceImpl) GetAllLoanApplication(ctx context.Context, purpose string,
 	return res, totalItems, nil
 }
 
-func checkEligibility(req LoanApplicationEntity) (bool, string) {
-	if req.MonthlyIncome < 10000 {
-		return false, ErrMonthlyIncomeInsufficient
+// employment is nil for applicants who gave no employment details.
+func employment(application LoanApplicationEntity) *Employment {
+	if application.EmploymentType == "" && application.EmployerName == "" && application.EmploymentTenureMonths == 0 && application.IncomeFrequency == "" {
+		return nil
+	}
+	return &Employment{
+		Type:            application.EmploymentType,
+		EmployerName:    application.EmployerName,
+		TenureMonths:    application.EmploymentTenureMonths,
+		IncomeFrequency: application.IncomeFrequency,
+	}
+}
+
+// coApplicants shows co-applicants the way the applicant is shown, with
+// their national IDs masked.
+func (s *ServiceImpl) coApplicants(entities []LoanCoApplicantEntity, now time.Time) []CoApplicantResponse {
+	res := []CoApplicantResponse{}
+	for _, v := range entities {
+		coApplicant := CoApplicantResponse{
+			Role:          v.Role,
+			NationalId:    s.maskedNationalId(v.NationalIdEncrypted),
+			FullName:      v.FullName,
+			GivenNameTh:   v.GivenNameTh,
+			FamilyNameTh:  v.FamilyNameTh,
+			GivenNameEn:   v.GivenNameEn,
+			FamilyNameEn:  v.FamilyNameEn,
+			PhoneNumber:   v.PhoneNumber,
+			PhoneE164:     v.PhoneE164,
+			PhoneType:     v.PhoneType,
+			Email:         v.Email,
+			MonthlyIncome: v.Income(),
+		}
+		if v.DateOfBirth.Valid {
+			coApplicant.DateOfBirth = v.DateOfBirth.Time.Format(birthdate.Layout)
+			coApplicant.Age = birthdate.AgeAt(v.DateOfBirth.Time, now)
+		}
+		res = append(res, coApplicant)
+	}
+	return res
+}
+
+func dateOfBirth(application LoanApplicationEntity) string {
+	if !application.DateOfBirth.Valid {
+		return ""
 	}
-	if req.Age < 20 || req.Age > 60 {
-		return false, ErrAgeNotInRange
+	return application.DateOfBirth.Time.Format(birthdate.Layout)
+}
+
+// currentAge is worked out from the date of birth rather than read from the
+// age column, which only holds the age at application time.
+func currentAge(application LoanApplicationEntity, now time.Time) int {
+	if !application.DateOfBirth.Valid {
+		return application.Age
 	}
-	if req.LoanPurpose == "business" {
-		return false, ErrBusinessLoansNotSupported
+	return birthdate.AgeAt(application.DateOfBirth.Time, now)
+}
+
+// maskedNationalId shows the last digits of a stored national ID. An ID that
+// cannot be decrypted is logged and left out of the response.
+func (s *ServiceImpl) maskedNationalId(sealed []byte) string {
+	if sealed == nil || s.nationalIds == nil {
+		return ""
 	}
-	if req.LoanAmount > 12*req.MonthlyIncome {
-		return false, ErrLoanAmountExceedsCap
+	id, err := s.nationalIds.Open(sealed)
+	if err != nil {
+		log.Println("err: ", err)
+		return ""
+	}
+	return nationalid.Mask(id)
+}
+
+// checkEligibility returns the decision stored with the application, and
+// only re-evaluates applications stored before decisions were persisted.
+// Those predate the product catalogue, so no product overrides apply.
+// Drafts are not evaluated until they are submitted.
+func (s *ServiceImpl) checkEligibility(req LoanApplicationEntity) (bool, string) {
+	if req.Eligible.Valid {
+		return req.Eligible.Bool, req.Reason.String
+	}
+	if req.Status == store.ApplicationDraft {
+		return false, ""
+	}
+
+	decision, err := s.engine.Evaluate(eligibility.Applicant{
+		MonthlyIncome: req.Income(),
+		LoanAmount:    req.Loan(),
+		LoanPurpose:   req.LoanPurpose,
+		DateOfBirth:   req.DateOfBirth.Time,
+		Age:           req.Age,
+	}, eligibility.RuleOverrides{}, req.Timestamp)
+	if err != nil {
+		return false, err.Error()
 	}
-	return true, EligibleUnderBaseRules
+	return decision.Eligible, decision.Reason
 }
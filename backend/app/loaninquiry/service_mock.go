@@ -19,7 +19,7 @@ func (m *MockService) GetLoanApplicationWithAppId(ctx context.Context, applicati
 	return args.Get(0).(ApplicationResponse), args.Error(1)
 }
 
-func (m *MockService) GetAllLoanApplication(ctx context.Context, purpose string, limit int, offset int) ([]ApplicationResponse, int, error) {
-	args := m.Called(ctx, purpose, limit, offset)
+func (m *MockService) GetAllLoanApplication(ctx context.Context, purpose string, nationalId string, limit int, offset int) ([]ApplicationResponse, int, error) {
+	args := m.Called(ctx, purpose, nationalId, limit, offset)
 	return args.Get(0).([]ApplicationResponse), args.Get(1).(int), args.Error(2)
 }
@@ -0,0 +1,17 @@
+package loanoffer
+
+import "errors"
+
+const (
+	MsgInvalidBody      = "Invalid request body"
+	MsgOfferNotAccepted = "Offer could not be accepted"
+)
+
+var (
+	ErrApplicationNotFound   = errors.New("Loan application not found")
+	ErrOfferNotFound         = errors.New("Loan application has no counter-offer")
+	ErrOfferOptionNotFound   = errors.New("Offer option not found")
+	ErrOfferAlreadyAccepted  = errors.New("Offer already accepted")
+	ErrOfferExpired          = errors.New("Offer expired")
+	ErrOfferNoLongerEligible = errors.New("Offer no longer eligible")
+)
@@ -0,0 +1,49 @@
+package loanoffer
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) AcceptOffer(c *gin.Context) {
+
+	applicationId := c.Param("applicationId")
+
+	var req HttpRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Option == "" {
+		c.JSON(http.StatusBadRequest, HttpBadResponse{
+			Message: MsgInvalidBody,
+			Reason:  "missing required fields: option",
+		})
+		return
+	}
+
+	res, err := h.service.AcceptOffer(c.Request.Context(), applicationId, req.Option)
+	if err != nil {
+		log.Println("err: ", err)
+		switch {
+		case errors.Is(err, ErrApplicationNotFound):
+			c.JSON(http.StatusNotFound, HttpBadResponse{Message: MsgOfferNotAccepted, Reason: err.Error()})
+		case errors.Is(err, ErrOfferNotFound), errors.Is(err, ErrOfferOptionNotFound):
+			c.JSON(http.StatusBadRequest, HttpBadResponse{Message: MsgOfferNotAccepted, Reason: err.Error()})
+		case errors.Is(err, ErrOfferAlreadyAccepted), errors.Is(err, ErrOfferExpired), errors.Is(err, ErrOfferNoLongerEligible):
+			c.JSON(http.StatusConflict, HttpBadResponse{Message: MsgOfferNotAccepted, Reason: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,135 @@
+package loanoffer
+
+import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/app/notifications"
+	"backend-loan-pre-approval/app/products"
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/fx"
+	"backend-loan-pre-approval/pkg/money"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+func newOfferedApplication(expiresAt time.Time) LoanApplicationEntity {
+	offer, _ := json.Marshal(eligibility.Offer{
+		Options: []eligibility.OfferOption{
+			{Type: eligibility.OfferReducedAmount, LoanAmount: money.FromMajor(60000, money.DefaultCurrency), TermMonths: 12},
+		},
+		ExpiresAt: expiresAt,
+	})
+	return LoanApplicationEntity{
+		ApplicationId:         "app-1",
+		MonthlyIncome:         money.FromMajor(11000, money.DefaultCurrency).Amount,
+		MonthlyIncomeCurrency: money.DefaultCurrency,
+		LoanAmount:            money.FromMajor(200000, money.DefaultCurrency).Amount,
+		LoanAmountCurrency:    money.DefaultCurrency,
+		LoanPurpose:           "home",
+		TermMonths:            12,
+		Age:                   25,
+		Offer:                 types.NullJSONText{JSONText: offer, Valid: true},
+	}
+}
+
+func newTestHandler(mockRepo *MockRepo) *Handler {
+	return newTestHandlerWithNotifier(mockRepo, nil)
+}
+
+func newTestHandlerWithNotifier(mockRepo *MockRepo, notifier notifications.Notifier) *Handler {
+	mockTx := database.NewMockTransactor()
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	mockProducts := products.NewMockService()
+	mockProducts.On("GetActiveProduct", mock.Anything, "home", mock.Anything).Return(products.Product{Code: "home"}, nil)
+	rates, _ := fx.NewTable(money.DefaultCurrency, nil)
+
+	return NewHandler(NewService(mockRepo, mockTx, eligibility.NewEngine(eligibility.DefaultRuleset(), rates, nil), mockProducts, notifier))
+}
+
+func acceptOffer(h *Handler, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/loans/:applicationId/offer/accept", h.AcceptOffer)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/loans/app-1/offer/accept", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	return resp
+}
+
+func Test_AcceptOffer_SUCCESS(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockRepo.On("GetLoanApplicationForUpdate", mock.Anything, "app-1").Return(newOfferedApplication(time.Now().Add(time.Hour)), nil)
+	mockRepo.On("GetLoanObligations", mock.Anything, "app-1").Return([]LoanObligationEntity{}, nil)
+	mockRepo.On("GetLoanCoApplicants", mock.Anything, "app-1").Return([]LoanCoApplicantEntity{}, nil)
+	mockRepo.On("GetCreditReport", mock.Anything, "app-1").Return((*CreditReportEntity)(nil), nil)
+	mockRepo.On("AcceptLoanOffer", mock.Anything, mock.MatchedBy(func(e LoanApplicationEntity) bool {
+		return e.LoanAmount == 6000000 && e.OfferAcceptedAt.Valid && e.Eligible.Bool
+	})).Return(nil)
+	mockNotifier := notifications.NewMockService()
+	mockNotifier.On("Notify", mock.Anything, "app-1", notifications.EventOfferAccepted).Return(nil)
+
+	resp := acceptOffer(newTestHandlerWithNotifier(mockRepo, mockNotifier), `{"option": "reducedAmount"}`)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, true, response["eligible"])
+	assert.Equal(t, "60000.00", response["loanAmount"].(map[string]interface{})["amount"])
+	mockRepo.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+}
+
+func Test_AcceptOffer_Expired(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockRepo.On("GetLoanApplicationForUpdate", mock.Anything, "app-1").Return(newOfferedApplication(time.Now().Add(-time.Hour)), nil)
+
+	resp := acceptOffer(newTestHandler(mockRepo), `{"option": "reducedAmount"}`)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Equal(t, ErrOfferExpired.Error(), response["reason"])
+	mockRepo.AssertNotCalled(t, "AcceptLoanOffer", mock.Anything, mock.Anything)
+}
+
+func Test_AcceptOffer_AlreadyAccepted(t *testing.T) {
+	application := newOfferedApplication(time.Now().Add(time.Hour))
+	application.OfferAcceptedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	mockRepo := NewMockRepo()
+	mockRepo.On("GetLoanApplicationForUpdate", mock.Anything, "app-1").Return(application, nil)
+
+	resp := acceptOffer(newTestHandler(mockRepo), `{"option": "reducedAmount"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
+
+func Test_AcceptOffer_UnknownOption(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockRepo.On("GetLoanApplicationForUpdate", mock.Anything, "app-1").Return(newOfferedApplication(time.Now().Add(time.Hour)), nil)
+
+	resp := acceptOffer(newTestHandler(mockRepo), `{"option": "extendedTerm"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
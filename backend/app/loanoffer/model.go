@@ -0,0 +1,42 @@
+package loanoffer
+
+import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/pkg/amortization"
+	"backend-loan-pre-approval/pkg/money"
+)
+
+// ======= sample request ======== //
+// {
+// 	"option": "reducedAmount"
+// }
+
+type HttpRequest struct {
+	Option string `json:"option"`
+}
+
+// ======== sample response ======== //
+// {
+// 	"applicationId": "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+// 	"eligible": true,
+// 	"reason": "Eligible under base rules",
+// 	"loanAmount": {"amount": "84000.00", "currency": "THB"},
+// 	"termMonths": 12,
+// 	"quote": {...},
+// 	"checks": [...]
+// }
+
+type HttpResponse struct {
+	ApplicationId string                 `json:"applicationId"`
+	Eligible      bool                   `json:"eligible"`
+	Reason        string                 `json:"reason"`
+	LoanAmount    money.Money            `json:"loanAmount"`
+	TermMonths    int                    `json:"termMonths"`
+	Quote         *amortization.Schedule `json:"quote,omitempty"`
+	Checks        []eligibility.Check    `json:"checks,omitempty"`
+}
+
+type HttpBadResponse struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
@@ -0,0 +1,90 @@
+package loanoffer
+
+import (
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetLoanApplicationForUpdate(ctx context.Context, applicationId string) (LoanApplicationEntity, error)
+	GetLoanObligations(ctx context.Context, applicationId string) ([]LoanObligationEntity, error)
+	GetLoanCoApplicants(ctx context.Context, applicationId string) ([]LoanCoApplicantEntity, error)
+	GetCreditReport(ctx context.Context, applicationId string) (*CreditReportEntity, error)
+	AcceptLoanOffer(ctx context.Context, LoanApplication LoanApplicationEntity) error
+}
+
+type RepositoryImpl struct {
+	queries *store.Queries
+}
+
+func NewRepository(db *sqlx.DB) Repository {
+	return &RepositoryImpl{
+		queries: store.New(db),
+	}
+}
+
+func (r *RepositoryImpl) GetLoanApplicationForUpdate(ctx context.Context, applicationId string) (LoanApplicationEntity, error) {
+
+	loanApplication, err := r.queries.GetLoanApplicationForUpdate(ctx, applicationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return LoanApplicationEntity{}, ErrApplicationNotFound
+		}
+		log.Println("err: ", err)
+		return LoanApplicationEntity{}, err
+	}
+
+	return loanApplication, nil
+}
+
+func (r *RepositoryImpl) GetLoanObligations(ctx context.Context, applicationId string) ([]LoanObligationEntity, error) {
+
+	obligations, err := r.queries.ListLoanObligations(ctx, applicationId)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return obligations, nil
+}
+
+func (r *RepositoryImpl) GetLoanCoApplicants(ctx context.Context, applicationId string) ([]LoanCoApplicantEntity, error) {
+
+	coApplicants, err := r.queries.ListLoanCoApplicants(ctx, applicationId)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return coApplicants, nil
+}
+
+// GetCreditReport returns nil when no report was stored for the application.
+func (r *RepositoryImpl) GetCreditReport(ctx context.Context, applicationId string) (*CreditReportEntity, error) {
+
+	report, err := r.queries.GetCreditReport(ctx, applicationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func (r *RepositoryImpl) AcceptLoanOffer(ctx context.Context, LoanApplication LoanApplicationEntity) error {
+
+	if err := r.queries.AcceptLoanOffer(ctx, LoanApplication); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package loanoffer
+
+import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/app/notifications"
+	"backend-loan-pre-approval/app/products"
+	"backend-loan-pre-approval/pkg/amortization"
+	"backend-loan-pre-approval/pkg/creditbureau"
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/money"
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+)
+
+type Service interface {
+	AcceptOffer(ctx context.Context, applicationId string, option string) (HttpResponse, error)
+}
+
+type ServiceImpl struct {
+	repository Repository
+	transactor database.Transactor
+	engine     *eligibility.Engine
+	products   products.Service
+	notifier   notifications.Notifier
+}
+
+// NewService builds the offer service. notifier may be nil, in which case
+// applicants are not told when they accept an offer.
+func NewService(repository Repository, transactor database.Transactor, engine *eligibility.Engine, products products.Service, notifier notifications.Notifier) Service {
+	return &ServiceImpl{
+		repository: repository,
+		transactor: transactor,
+		engine:     engine,
+		products:   products,
+		notifier:   notifier,
+	}
+}
+
+// AcceptOffer re-evaluates the application with the chosen offer option and,
+// if it is still eligible, stores the new amount, term and decision. The row
+// is locked for the duration so an offer can only be accepted once.
+func (s *ServiceImpl) AcceptOffer(ctx context.Context, applicationId string, option string) (HttpResponse, error) {
+
+	var res HttpResponse
+	err := s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+
+		application, err := s.repository.GetLoanApplicationForUpdate(ctx, applicationId)
+		if err != nil {
+			return err
+		}
+		if !application.Offer.Valid {
+			return ErrOfferNotFound
+		}
+		if application.OfferAcceptedAt.Valid {
+			return ErrOfferAlreadyAccepted
+		}
+
+		var offer eligibility.Offer
+		if err := json.Unmarshal(application.Offer.JSONText, &offer); err != nil {
+			return err
+		}
+		if now.After(offer.ExpiresAt) {
+			return ErrOfferExpired
+		}
+		chosen, ok := offer.Option(option)
+		if !ok {
+			return ErrOfferOptionNotFound
+		}
+
+		product, err := s.products.GetActiveProduct(ctx, application.LoanPurpose, now)
+		if err != nil {
+			return ErrOfferNoLongerEligible
+		}
+
+		obligations, err := s.repository.GetLoanObligations(ctx, applicationId)
+		if err != nil {
+			return err
+		}
+		existing := []money.Money{}
+		for _, v := range obligations {
+			existing = append(existing, v.Payment())
+		}
+		coApplicants, err := s.repository.GetLoanCoApplicants(ctx, applicationId)
+		if err != nil {
+			return err
+		}
+
+		// The stored report is reused rather than fetched again, so the
+		// offer is judged on the same bureau data it was made with.
+		stored, err := s.repository.GetCreditReport(ctx, applicationId)
+		if err != nil {
+			return err
+		}
+		var report *creditbureau.Report
+		if stored != nil {
+			r := stored.ToReport()
+			report = &r
+		}
+
+		decision, err := s.engine.Evaluate(eligibility.Applicant{
+			MonthlyIncome:  application.Income(),
+			LoanAmount:     chosen.LoanAmount,
+			LoanPurpose:    application.LoanPurpose,
+			DateOfBirth:    application.DateOfBirth.Time,
+			Age:            application.Age,
+			EmploymentType: application.EmploymentType,
+			TenureMonths:   application.EmploymentTenureMonths,
+			TermMonths:     chosen.TermMonths,
+			AnnualRateBps:  application.InterestRateBps,
+
+			ExistingObligations: existing,
+			CoBorrowerIncomes:   store.CoBorrowerIncomes(coApplicants),
+			CreditReport:        report,
+		}, product.RuleOverrides, now)
+		if err != nil {
+			return err
+		}
+		if !decision.Eligible {
+			return ErrOfferNoLongerEligible
+		}
+
+		decisionJSON, err := json.Marshal(decision)
+		if err != nil {
+			return err
+		}
+
+		application.LoanAmount = chosen.LoanAmount.Amount
+		application.LoanAmountCurrency = chosen.LoanAmount.Currency
+		application.TermMonths = chosen.TermMonths
+		application.Eligible = sql.NullBool{Bool: true, Valid: true}
+		application.Reason = sql.NullString{String: decision.Reason, Valid: true}
+		application.Decision = types.NullJSONText{JSONText: decisionJSON, Valid: true}
+		application.Outcome = sql.NullString{String: decision.Outcome, Valid: true}
+		if decision.Scorecard != nil {
+			application.RiskScore = sql.NullInt32{Int32: int32(decision.Scorecard.Score), Valid: true}
+			application.RiskGrade = sql.NullString{String: decision.Scorecard.Grade, Valid: true}
+		}
+		application.OfferAcceptedAt = sql.NullTime{Time: now, Valid: true}
+		if err := s.repository.AcceptLoanOffer(ctx, application); err != nil {
+			return err
+		}
+		if s.notifier != nil {
+			if err := s.notifier.Notify(ctx, applicationId, notifications.EventOfferAccepted); err != nil {
+				return err
+			}
+		}
+
+		quote, err := amortization.Calculate(chosen.LoanAmount, application.InterestRateBps, chosen.TermMonths)
+		if err != nil {
+			return err
+		}
+
+		res = HttpResponse{
+			ApplicationId: applicationId,
+			Eligible:      true,
+			Reason:        decision.Reason,
+			LoanAmount:    chosen.LoanAmount,
+			TermMonths:    chosen.TermMonths,
+			Quote:         &quote,
+			Checks:        decision.Checks,
+		}
+		return nil
+	})
+	if err != nil {
+		return HttpResponse{}, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,20 @@
+package loanoffer
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockService struct {
+	mock.Mock
+}
+
+func NewMockService() *MockService {
+	return &MockService{}
+}
+
+func (m *MockService) AcceptOffer(ctx context.Context, applicationId string, option string) (HttpResponse, error) {
+	args := m.Called(ctx, applicationId, option)
+	return args.Get(0).(HttpResponse), args.Error(1)
+}
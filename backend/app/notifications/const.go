@@ -0,0 +1,65 @@
+package notifications
+
+import (
+	"backend-loan-pre-approval/pkg/scorecard"
+	"errors"
+	"time"
+)
+
+const (
+	MsgRequestFailed = "Notification request failed"
+)
+
+// Events are what an applicant is notified of. Each has a template per
+// locale.
+const (
+	EventApproved      = "approved"
+	EventDeclined      = "declined"
+	EventReferred      = "referred"
+	EventOfferAccepted = "offerAccepted"
+)
+
+var Events = []string{EventApproved, EventDeclined, EventReferred, EventOfferAccepted}
+
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+const (
+	LocaleThai    = "th"
+	LocaleEnglish = "en"
+)
+
+var Locales = []string{LocaleThai, LocaleEnglish}
+
+const (
+	// MaxAttempts is how many times a notification is tried before it is
+	// marked failed.
+	MaxAttempts = 5
+	// RetryBackoff is the wait after the first failed attempt; it doubles
+	// after each further one.
+	RetryBackoff = time.Minute
+	// SendLease is how long a dispatcher holds a notification it is
+	// sending before another may pick it up.
+	SendLease         = 5 * time.Minute
+	DispatchBatchSize = 20
+)
+
+var (
+	ErrApplicationNotFound = errors.New("Loan application not found")
+	ErrNoSender            = errors.New("No sender is configured for the channel")
+)
+
+// EventForOutcome returns the event announcing a decision with the given
+// outcome.
+func EventForOutcome(outcome string) string {
+	switch outcome {
+	case scorecard.OutcomeApprove:
+		return EventApproved
+	case scorecard.OutcomeRefer:
+		return EventReferred
+	default:
+		return EventDeclined
+	}
+}
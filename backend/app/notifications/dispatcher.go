@@ -0,0 +1,36 @@
+package notifications
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StartDispatcher sends due notifications every interval until ctx is done.
+// An interval of 0 disables it, leaving notifications pending.
+func StartDispatcher(ctx context.Context, service Service, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sent, err := service.DispatchDue(ctx)
+				if err != nil {
+					log.Println("err: ", err)
+					continue
+				}
+				if sent > 0 {
+					log.Printf("sent %d notifications", sent)
+				}
+			}
+		}
+	}()
+}
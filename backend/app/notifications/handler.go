@@ -0,0 +1,35 @@
+package notifications
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetNotifications(c *gin.Context) {
+
+	applicationId := c.Param("applicationId")
+
+	notifications, err := h.service.GetNotifications(c.Request.Context(), applicationId)
+	if err != nil {
+		log.Println("err: ", err)
+		if errors.Is(err, ErrApplicationNotFound) {
+			c.JSON(http.StatusNotFound, HttpBadResponse{Message: MsgRequestFailed, Reason: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, GetNotificationsResponse{Notifications: notifications})
+}
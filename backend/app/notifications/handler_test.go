@@ -0,0 +1,235 @@
+package notifications
+
+import (
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/money"
+	"backend-loan-pre-approval/pkg/notify"
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+// recordingSender stands in for both an email and an SMS provider. It keeps
+// what it was given and fails every send while err is set.
+type recordingSender struct {
+	emails []notify.Email
+	sms    []notify.SMS
+	err    error
+}
+
+func (r *recordingSender) Name() string {
+	return "recording"
+}
+
+func (r *recordingSender) SendEmail(ctx context.Context, email notify.Email) error {
+	r.emails = append(r.emails, email)
+	return r.err
+}
+
+func (r *recordingSender) SendSMS(ctx context.Context, sms notify.SMS) error {
+	r.sms = append(r.sms, sms)
+	return r.err
+}
+
+func newTestService(mockRepo *MockRepo, email notify.EmailSender, sms notify.SMSSender) *ServiceImpl {
+	mockTx := database.NewMockTransactor()
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+	s := NewService(mockRepo, mockTx, email, sms, LocaleThai).(*ServiceImpl)
+	s.now = func() time.Time { return testNow }
+	return s
+}
+
+func createdNotifications(mockRepo *MockRepo) []LoanNotificationEntity {
+	created := []LoanNotificationEntity{}
+	for _, c := range mockRepo.Calls {
+		if c.Method == "CreateLoanNotification" {
+			created = append(created, c.Arguments.Get(1).(LoanNotificationEntity))
+		}
+	}
+	return created
+}
+
+var sampleApplication = LoanApplicationEntity{
+	ApplicationId:      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	FullName:           "Somkanit Jitsanook",
+	GivenNameTh:        "สมคนิต",
+	FamilyNameTh:       "จิตสนุก",
+	GivenNameEn:        "Somkanit",
+	FamilyNameEn:       "Jitsanook",
+	LoanAmount:         1000000,
+	LoanAmountCurrency: money.DefaultCurrency,
+	TermMonths:         24,
+	PhoneE164:          "+66851234567",
+	Email:              "demo@example.com",
+}
+
+func Test_Templates(t *testing.T) {
+	for _, locale := range Locales {
+		for _, event := range Events {
+			msg, err := render(locale, event, templateData{Name: "Somkanit", Reference: "3FA85F64", LoanAmount: "10000.00 THB", TermMonths: 24})
+
+			// Assert
+			assert.NilError(t, err, locale+"/"+event)
+			assert.Assert(t, msg.Subject != "" && msg.Email != "" && msg.SMS != "", locale+"/"+event)
+			assert.Assert(t, strings.Contains(msg.SMS, "3FA85F64"), locale+"/"+event)
+		}
+	}
+}
+
+func Test_Notify(t *testing.T) {
+	mockRepo := NewMockRepo()
+	sender := &recordingSender{}
+	s := newTestService(mockRepo, sender, sender)
+
+	english := sampleApplication
+	english.Locale = LocaleEnglish
+	mockRepo.On("GetLoanApplication", mock.Anything, "app-en").Return(english, nil)
+	mockRepo.On("GetLoanApplication", mock.Anything, "app-th").Return(sampleApplication, nil)
+	mockRepo.On("CreateLoanNotification", mock.Anything, mock.Anything).Return(nil)
+
+	assert.NilError(t, s.Notify(t.Context(), "app-en", EventApproved))
+	assert.NilError(t, s.Notify(t.Context(), "app-th", EventDeclined))
+	created := createdNotifications(mockRepo)
+
+	// Assert
+	assert.Equal(t, 4, len(created))
+	assert.Equal(t, ChannelEmail, created[0].Channel)
+	assert.Equal(t, "demo@example.com", created[0].Recipient)
+	assert.Equal(t, LocaleEnglish, created[0].Locale)
+	assert.Equal(t, "Your loan application has been approved", created[0].Subject)
+	assert.Assert(t, strings.HasPrefix(created[0].Body, "Dear Somkanit Jitsanook,\n"))
+	assert.Assert(t, strings.Contains(created[0].Body, "10000.00 THB over 24 months"))
+	assert.Equal(t, store.NotificationPending, created[0].Status)
+	assert.Equal(t, testNow, created[0].NextAttemptAt)
+	assert.Equal(t, ChannelSMS, created[1].Channel)
+	assert.Equal(t, "+66851234567", created[1].Recipient)
+	assert.Equal(t, "", created[1].Subject)
+	// Applications without a locale are written to in the default one.
+	assert.Equal(t, LocaleThai, created[2].Locale)
+	assert.Assert(t, strings.HasPrefix(created[2].Body, "เรียน คุณสมคนิต จิตสนุก\n"))
+	assert.Equal(t, EventDeclined, created[3].Event)
+}
+
+func Test_Notify_SkipsChannels(t *testing.T) {
+	mockRepo := NewMockRepo()
+	s := newTestService(mockRepo, &recordingSender{}, nil)
+
+	noEmail := sampleApplication
+	noEmail.Email = ""
+	mockRepo.On("GetLoanApplication", mock.Anything, "app-1").Return(noEmail, nil)
+	mockRepo.On("GetLoanApplication", mock.Anything, "app-2").Return(sampleApplication, nil)
+	mockRepo.On("CreateLoanNotification", mock.Anything, mock.Anything).Return(nil)
+
+	assert.NilError(t, s.Notify(t.Context(), "app-1", EventReferred))
+	assert.NilError(t, s.Notify(t.Context(), "app-2", EventReferred))
+	created := createdNotifications(mockRepo)
+
+	// Assert
+	// No email address for app-1 and no SMS sender for either.
+	assert.Equal(t, 1, len(created))
+	assert.Equal(t, ChannelEmail, created[0].Channel)
+	assert.Equal(t, "app-2", created[0].ApplicationId)
+}
+
+func Test_DispatchDue(t *testing.T) {
+	mockRepo := NewMockRepo()
+	email := &recordingSender{}
+	sms := &recordingSender{err: errors.New("gateway unavailable")}
+	s := newTestService(mockRepo, email, sms)
+
+	due := []LoanNotificationEntity{
+		{NotificationId: "n-1", ApplicationId: "app-1", Channel: ChannelEmail, Recipient: "demo@example.com", Subject: "s", Body: "b", Status: store.NotificationPending},
+		{NotificationId: "n-2", ApplicationId: "app-1", Channel: ChannelSMS, Recipient: "+66851234567", Body: "b", Status: store.NotificationPending, Attempts: 2},
+		{NotificationId: "n-3", ApplicationId: "app-2", Channel: ChannelSMS, Recipient: "+66869876543", Body: "b", Status: store.NotificationPending, Attempts: MaxAttempts - 1},
+	}
+	mockRepo.On("ClaimDueLoanNotifications", mock.Anything, testNow, testNow.Add(SendLease), DispatchBatchSize).Return(due, nil)
+	mockRepo.On("UpdateLoanNotificationDelivery", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateLoanNotificationAttempt", mock.Anything, mock.Anything).Return(nil)
+
+	sent, err := s.DispatchDue(t.Context())
+
+	updated := map[string]LoanNotificationEntity{}
+	attempts := map[string]LoanNotificationAttemptEntity{}
+	for _, c := range mockRepo.Calls {
+		switch c.Method {
+		case "UpdateLoanNotificationDelivery":
+			n := c.Arguments.Get(1).(LoanNotificationEntity)
+			updated[n.NotificationId] = n
+		case "CreateLoanNotificationAttempt":
+			a := c.Arguments.Get(1).(LoanNotificationAttemptEntity)
+			attempts[a.NotificationId] = a
+		}
+	}
+
+	// Assert
+	assert.NilError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.DeepEqual(t, []notify.Email{{To: "demo@example.com", Subject: "s", Body: "b"}}, email.emails)
+	assert.Equal(t, store.NotificationSent, updated["n-1"].Status)
+	assert.Equal(t, testNow, updated["n-1"].SentAt.Time)
+	assert.Equal(t, true, attempts["n-1"].Succeeded)
+	assert.Equal(t, "recording", attempts["n-1"].Provider)
+	// The third attempt waits twice as long as the second.
+	assert.Equal(t, store.NotificationPending, updated["n-2"].Status)
+	assert.Equal(t, 3, updated["n-2"].Attempts)
+	assert.Equal(t, testNow.Add(4*RetryBackoff), updated["n-2"].NextAttemptAt)
+	assert.Equal(t, "gateway unavailable", attempts["n-2"].Error)
+	assert.Equal(t, store.NotificationFailed, updated["n-3"].Status)
+	assert.Equal(t, false, attempts["n-3"].Succeeded)
+}
+
+func Test_GetNotifications(t *testing.T) {
+	mockRepo := NewMockRepo()
+	s := newTestService(mockRepo, nil, nil)
+	h := NewHandler(s)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/loans/:applicationId/notifications", h.GetNotifications)
+
+	mockRepo.On("GetLoanApplication", mock.Anything, "app-1").Return(LoanApplicationEntity{ApplicationId: "app-1"}, nil)
+	mockRepo.On("GetLoanApplication", mock.Anything, "app-2").Return(LoanApplicationEntity{}, ErrApplicationNotFound)
+	mockRepo.On("GetLoanNotifications", mock.Anything, "app-1").Return([]LoanNotificationEntity{
+		{NotificationId: "n-1", Channel: ChannelEmail, Status: store.NotificationSent, Attempts: 2, SentAt: sql.NullTime{Time: testNow, Valid: true}},
+		{NotificationId: "n-2", Channel: ChannelSMS, Status: store.NotificationPending, NextAttemptAt: testNow},
+	}, nil)
+	mockRepo.On("GetLoanNotificationAttempts", mock.Anything, "app-1").Return([]LoanNotificationAttemptEntity{
+		{NotificationId: "n-1", Provider: "smtp", Error: "connection refused"},
+		{NotificationId: "n-1", Provider: "smtp", Succeeded: true},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "http://0.0.0.0/api/v1/loans/app-1/notifications", nil)
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	missingReq := httptest.NewRequest(http.MethodGet, "http://0.0.0.0/api/v1/loans/app-2/notifications", nil)
+	missing := httptest.NewRecorder()
+	r.ServeHTTP(missing, missingReq)
+
+	var response GetNotificationsResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, 2, len(response.Notifications))
+	assert.Equal(t, 2, len(response.Notifications[0].Attempts))
+	assert.Equal(t, "connection refused", response.Notifications[0].Attempts[0].Error)
+	assert.Assert(t, response.Notifications[0].NextAttemptAt == nil)
+	assert.Equal(t, 0, len(response.Notifications[1].Attempts))
+	assert.Assert(t, response.Notifications[1].NextAttemptAt != nil)
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+}
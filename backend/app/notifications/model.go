@@ -0,0 +1,58 @@
+package notifications
+
+import "time"
+
+// ======== sample notifications response ======== //
+//
+//	GET /api/v1/loans/{applicationId}/notifications
+//
+//	{
+//		"notifications": [
+//			{
+//				"notificationId": "0c8f5a52-3b7e-4f0e-9d1a-6a2c9e4b7f10",
+//				"event": "approved",
+//				"channel": "email",
+//				"locale": "th",
+//				"recipient": "demo@example.com",
+//				"status": "sent",
+//				"createdAt": "2025-07-19T19:34:56+07:00",
+//				"sentAt": "2025-07-19T19:35:06+07:00",
+//				"attempts": [
+//					{"provider": "smtp", "attemptedAt": "2025-07-19T19:35:01+07:00", "succeeded": false, "error": "dial tcp: connection refused"},
+//					{"provider": "smtp", "attemptedAt": "2025-07-19T19:35:06+07:00", "succeeded": true}
+//				]
+//			}
+//		]
+//	}
+//
+// status is pending until the notification is sent, or failed once it has
+// used up MaxAttempts. nextAttemptAt is set while it is pending.
+type Notification struct {
+	NotificationId string     `json:"notificationId"`
+	Event          string     `json:"event"`
+	Channel        string     `json:"channel"`
+	Locale         string     `json:"locale"`
+	Recipient      string     `json:"recipient"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty"`
+	SentAt         *time.Time `json:"sentAt,omitempty"`
+	Attempts       []Attempt  `json:"attempts"`
+}
+
+// Attempt is one try at delivering a notification through Provider.
+type Attempt struct {
+	Provider    string    `json:"provider"`
+	AttemptedAt time.Time `json:"attemptedAt"`
+	Succeeded   bool      `json:"succeeded"`
+	Error       string    `json:"error,omitempty"`
+}
+
+type GetNotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+}
+
+type HttpBadResponse struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
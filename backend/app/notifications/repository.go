@@ -0,0 +1,109 @@
+package notifications
+
+import (
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetLoanApplication(ctx context.Context, applicationId string) (LoanApplicationEntity, error)
+	CreateLoanNotification(ctx context.Context, notification LoanNotificationEntity) error
+	ClaimDueLoanNotifications(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]LoanNotificationEntity, error)
+	UpdateLoanNotificationDelivery(ctx context.Context, notification LoanNotificationEntity) error
+	CreateLoanNotificationAttempt(ctx context.Context, attempt LoanNotificationAttemptEntity) error
+	GetLoanNotifications(ctx context.Context, applicationId string) ([]LoanNotificationEntity, error)
+	GetLoanNotificationAttempts(ctx context.Context, applicationId string) ([]LoanNotificationAttemptEntity, error)
+}
+
+type RepositoryImpl struct {
+	queries *store.Queries
+}
+
+func NewRepository(db *sqlx.DB) Repository {
+	return &RepositoryImpl{
+		queries: store.New(db),
+	}
+}
+
+func (r *RepositoryImpl) GetLoanApplication(ctx context.Context, applicationId string) (LoanApplicationEntity, error) {
+
+	loanApplication, err := r.queries.GetLoanApplication(ctx, applicationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return LoanApplicationEntity{}, ErrApplicationNotFound
+		}
+		log.Println("err: ", err)
+		return LoanApplicationEntity{}, err
+	}
+
+	return loanApplication, nil
+}
+
+func (r *RepositoryImpl) CreateLoanNotification(ctx context.Context, notification LoanNotificationEntity) error {
+
+	if err := r.queries.InsertLoanNotification(ctx, notification); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) ClaimDueLoanNotifications(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]LoanNotificationEntity, error) {
+
+	notifications, err := r.queries.ClaimDueLoanNotifications(ctx, now, leaseUntil, limit)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *RepositoryImpl) UpdateLoanNotificationDelivery(ctx context.Context, notification LoanNotificationEntity) error {
+
+	if err := r.queries.UpdateLoanNotificationDelivery(ctx, notification); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) CreateLoanNotificationAttempt(ctx context.Context, attempt LoanNotificationAttemptEntity) error {
+
+	if err := r.queries.InsertLoanNotificationAttempt(ctx, attempt); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) GetLoanNotifications(ctx context.Context, applicationId string) ([]LoanNotificationEntity, error) {
+
+	notifications, err := r.queries.ListLoanNotifications(ctx, applicationId)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *RepositoryImpl) GetLoanNotificationAttempts(ctx context.Context, applicationId string) ([]LoanNotificationAttemptEntity, error) {
+
+	attempts, err := r.queries.ListLoanNotificationAttempts(ctx, applicationId)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,9 @@
+package notifications
+
+import "backend-loan-pre-approval/pkg/store"
+
+type LoanApplicationEntity = store.LoanApplication
+
+type LoanNotificationEntity = store.LoanNotification
+
+type LoanNotificationAttemptEntity = store.LoanNotificationAttempt
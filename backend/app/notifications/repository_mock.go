@@ -0,0 +1,53 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepo struct {
+	mock.Mock
+}
+
+// Helper function to create a new repository with mocks
+func NewMockRepo() *MockRepo {
+
+	return &MockRepo{}
+}
+
+func (m *MockRepo) GetLoanApplication(ctx context.Context, applicationId string) (LoanApplicationEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).(LoanApplicationEntity), args.Error(1)
+}
+
+func (m *MockRepo) CreateLoanNotification(ctx context.Context, notification LoanNotificationEntity) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockRepo) ClaimDueLoanNotifications(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]LoanNotificationEntity, error) {
+	args := m.Called(ctx, now, leaseUntil, limit)
+	return args.Get(0).([]LoanNotificationEntity), args.Error(1)
+}
+
+func (m *MockRepo) UpdateLoanNotificationDelivery(ctx context.Context, notification LoanNotificationEntity) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockRepo) CreateLoanNotificationAttempt(ctx context.Context, attempt LoanNotificationAttemptEntity) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockRepo) GetLoanNotifications(ctx context.Context, applicationId string) ([]LoanNotificationEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).([]LoanNotificationEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetLoanNotificationAttempts(ctx context.Context, applicationId string) ([]LoanNotificationAttemptEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).([]LoanNotificationAttemptEntity), args.Error(1)
+}
@@ -0,0 +1,282 @@
+package notifications
+
+import (
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/notify"
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notifier is what the services that decide on or change an application
+// use to tell the applicant.
+type Notifier interface {
+	Notify(ctx context.Context, applicationId string, event string) error
+}
+
+type Service interface {
+	Notifier
+	DispatchDue(ctx context.Context) (int, error)
+	GetNotifications(ctx context.Context, applicationId string) ([]Notification, error)
+}
+
+type ServiceImpl struct {
+	repository    Repository
+	transactor    database.Transactor
+	email         notify.EmailSender
+	sms           notify.SMSSender
+	defaultLocale string
+	now           func() time.Time
+}
+
+// NewService builds the notifications service. email or sms may be nil, in
+// which case nothing is sent on that channel. Applicants who chose no
+// locale are written to in defaultLocale, or in Thai if that is not one of
+// Locales.
+func NewService(repository Repository, transactor database.Transactor, email notify.EmailSender, sms notify.SMSSender, defaultLocale string) Service {
+	if !isOneOf(defaultLocale, Locales) {
+		defaultLocale = LocaleThai
+	}
+	return &ServiceImpl{
+		repository:    repository,
+		transactor:    transactor,
+		email:         email,
+		sms:           sms,
+		defaultLocale: defaultLocale,
+		now:           time.Now,
+	}
+}
+
+// Notify renders event for the applicant and stores a pending notification
+// for each channel that has a sender and a recipient; DispatchDue sends
+// them. Called inside the transaction recording the event, the
+// notifications are kept only if the event is.
+func (s *ServiceImpl) Notify(ctx context.Context, applicationId string, event string) error {
+
+	application, err := s.repository.GetLoanApplication(ctx, applicationId)
+	if err != nil {
+		return err
+	}
+
+	locale := application.Locale
+	if !isOneOf(locale, Locales) {
+		locale = s.defaultLocale
+	}
+	msg, err := render(locale, event, templateData{
+		Name:       applicantName(application, locale),
+		Reference:  reference(applicationId),
+		LoanAmount: application.Loan().String(),
+		TermMonths: application.TermMonths,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	create := func(channel string, recipient string, subject string, body string) error {
+		return s.repository.CreateLoanNotification(ctx, LoanNotificationEntity{
+			NotificationId: uuid.New().String(),
+			ApplicationId:  applicationId,
+			Event:          event,
+			Channel:        channel,
+			Locale:         locale,
+			Recipient:      recipient,
+			Subject:        subject,
+			Body:           body,
+			Status:         store.NotificationPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+	if s.email != nil && application.Email != "" {
+		if err := create(ChannelEmail, application.Email, msg.Subject, msg.Email); err != nil {
+			return err
+		}
+	}
+	if s.sms != nil && application.PhoneE164 != "" {
+		if err := create(ChannelSMS, application.PhoneE164, "", msg.SMS); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DispatchDue tries to send up to DispatchBatchSize notifications that are
+// due and returns how many were sent. Every attempt is recorded; a failed
+// one is retried after a growing delay until MaxAttempts is reached.
+func (s *ServiceImpl) DispatchDue(ctx context.Context) (int, error) {
+
+	now := s.now()
+	due, err := s.repository.ClaimDueLoanNotifications(ctx, now, now.Add(SendLease), DispatchBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, v := range due {
+		delivered, err := s.deliver(ctx, v)
+		if err != nil {
+			log.Println("err: ", err)
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *ServiceImpl) deliver(ctx context.Context, notification LoanNotificationEntity) (bool, error) {
+
+	provider, sendErr := s.send(ctx, notification)
+	now := s.now()
+
+	attempt := LoanNotificationAttemptEntity{
+		AttemptId:      uuid.New().String(),
+		NotificationId: notification.NotificationId,
+		ApplicationId:  notification.ApplicationId,
+		Channel:        notification.Channel,
+		Provider:       provider,
+		AttemptedAt:    now,
+		Succeeded:      sendErr == nil,
+	}
+	notification.Attempts++
+	switch {
+	case sendErr == nil:
+		notification.Status = store.NotificationSent
+		notification.SentAt = sql.NullTime{Time: now, Valid: true}
+	case notification.Attempts >= MaxAttempts:
+		attempt.Error = sendErr.Error()
+		notification.Status = store.NotificationFailed
+		log.Printf("%s notification %s of application %s failed after %d attempts: %v", notification.Channel, notification.NotificationId, notification.ApplicationId, notification.Attempts, sendErr)
+	default:
+		attempt.Error = sendErr.Error()
+		notification.NextAttemptAt = now.Add(RetryBackoff << (notification.Attempts - 1))
+	}
+
+	err := s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repository.UpdateLoanNotificationDelivery(ctx, notification); err != nil {
+			return err
+		}
+		return s.repository.CreateLoanNotificationAttempt(ctx, attempt)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return sendErr == nil, nil
+}
+
+// send returns the name of the provider it used along with its error.
+func (s *ServiceImpl) send(ctx context.Context, notification LoanNotificationEntity) (string, error) {
+	switch notification.Channel {
+	case ChannelEmail:
+		if s.email == nil {
+			return "", ErrNoSender
+		}
+		return s.email.Name(), s.email.SendEmail(ctx, notify.Email{
+			To:      notification.Recipient,
+			Subject: notification.Subject,
+			Body:    notification.Body,
+		})
+	case ChannelSMS:
+		if s.sms == nil {
+			return "", ErrNoSender
+		}
+		return s.sms.Name(), s.sms.SendSMS(ctx, notify.SMS{
+			To:   notification.Recipient,
+			Text: notification.Body,
+		})
+	default:
+		return "", ErrNoSender
+	}
+}
+
+// GetNotifications lists the application's notifications, oldest first,
+// each with its delivery attempts.
+func (s *ServiceImpl) GetNotifications(ctx context.Context, applicationId string) ([]Notification, error) {
+
+	if _, err := s.repository.GetLoanApplication(ctx, applicationId); err != nil {
+		return []Notification{}, err
+	}
+
+	notifications, err := s.repository.GetLoanNotifications(ctx, applicationId)
+	if err != nil {
+		return []Notification{}, err
+	}
+	attempts, err := s.repository.GetLoanNotificationAttempts(ctx, applicationId)
+	if err != nil {
+		return []Notification{}, err
+	}
+
+	byNotification := map[string][]Attempt{}
+	for _, v := range attempts {
+		byNotification[v.NotificationId] = append(byNotification[v.NotificationId], Attempt{
+			Provider:    v.Provider,
+			AttemptedAt: v.AttemptedAt,
+			Succeeded:   v.Succeeded,
+			Error:       v.Error,
+		})
+	}
+
+	res := []Notification{}
+	for _, v := range notifications {
+		notification := Notification{
+			NotificationId: v.NotificationId,
+			Event:          v.Event,
+			Channel:        v.Channel,
+			Locale:         v.Locale,
+			Recipient:      v.Recipient,
+			Status:         v.Status,
+			CreatedAt:      v.CreatedAt,
+			Attempts:       byNotification[v.NotificationId],
+		}
+		if notification.Attempts == nil {
+			notification.Attempts = []Attempt{}
+		}
+		if v.Status == store.NotificationPending {
+			notification.NextAttemptAt = &v.NextAttemptAt
+		}
+		if v.SentAt.Valid {
+			notification.SentAt = &v.SentAt.Time
+		}
+		res = append(res, notification)
+	}
+
+	return res, nil
+}
+
+// applicantName is the name in the locale's script when the applicant gave
+// one, and the full name otherwise.
+func applicantName(application LoanApplicationEntity, locale string) string {
+	switch {
+	case locale == LocaleThai && application.GivenNameTh != "":
+		return application.GivenNameTh + " " + application.FamilyNameTh
+	case locale == LocaleEnglish && application.GivenNameEn != "":
+		return application.GivenNameEn + " " + application.FamilyNameEn
+	}
+	return application.FullName
+}
+
+// reference is the first block of the application ID, which is short
+// enough to quote over the phone.
+func reference(applicationId string) string {
+	block, _, _ := strings.Cut(applicationId, "-")
+	return strings.ToUpper(block)
+}
+
+func isOneOf(value string, values []string) bool {
+	for _, v := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
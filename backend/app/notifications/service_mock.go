@@ -0,0 +1,30 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockService struct {
+	mock.Mock
+}
+
+func NewMockService() *MockService {
+	return &MockService{}
+}
+
+func (m *MockService) Notify(ctx context.Context, applicationId string, event string) error {
+	args := m.Called(ctx, applicationId, event)
+	return args.Error(0)
+}
+
+func (m *MockService) DispatchDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockService) GetNotifications(ctx context.Context, applicationId string) ([]Notification, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).([]Notification), args.Error(1)
+}
@@ -0,0 +1,63 @@
+package notifications
+
+import (
+	"bytes"
+	"embed"
+	"strings"
+	"text/template"
+)
+
+// Each locale has one file defining "{event}.subject", "{event}.email" and
+// "{event}.sms" for every event.
+//
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = parseTemplates()
+
+func parseTemplates() map[string]*template.Template {
+	parsed := map[string]*template.Template{}
+	for _, locale := range Locales {
+		parsed[locale] = template.Must(template.New(locale).Option("missingkey=error").ParseFS(templateFS, "templates/"+locale+".tmpl"))
+	}
+	return parsed
+}
+
+// templateData is what the templates can refer to. Reference is the short
+// form of the application ID quoted to the applicant.
+type templateData struct {
+	Name       string
+	Reference  string
+	LoanAmount string
+	TermMonths int
+}
+
+// message is an event rendered in one locale.
+type message struct {
+	Subject string
+	Email   string
+	SMS     string
+}
+
+func render(locale string, event string, data templateData) (message, error) {
+	t := templates[locale]
+
+	var res message
+	for _, part := range []struct {
+		name string
+		dest *string
+	}{
+		{"subject", &res.Subject},
+		{"email", &res.Email},
+		{"sms", &res.SMS},
+	} {
+		var b bytes.Buffer
+		if err := t.ExecuteTemplate(&b, event+"."+part.name, data); err != nil {
+			return message{}, err
+		}
+		*part.dest = strings.TrimSpace(b.String())
+	}
+	res.Email += "\n"
+
+	return res, nil
+}
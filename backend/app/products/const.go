@@ -0,0 +1,7 @@
+package products
+
+const (
+	ErrReasonProductNotFound = "loan product not found: "
+	ErrReasonProductInactive = "loan product not active: "
+	ErrNoRows                = "no rows in result set"
+)
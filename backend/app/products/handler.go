@@ -0,0 +1,50 @@
+package products
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetProducts(c *gin.Context) {
+
+	result, err := h.service.GetActiveProducts(c.Request.Context(), time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	res := GetProductsResponse{Products: []ProductResponse{}}
+	for _, v := range result {
+		product := ProductResponse{
+			Code:            v.Code,
+			Name:            v.Name,
+			MinAmount:       v.MinAmount,
+			MaxAmount:       v.MaxAmount,
+			MinTermMonths:   v.MinTermMonths,
+			MaxTermMonths:   v.MaxTermMonths,
+			MinInterestRate: FormatRate(v.MinRateBps),
+			MaxInterestRate: FormatRate(v.MaxRateBps),
+			RuleOverrides:   v.RuleOverrides,
+			ActiveFrom:      v.ActiveFrom.Format(dateLayout),
+		}
+		if v.ActiveTo != nil {
+			activeTo := v.ActiveTo.Format(dateLayout)
+			product.ActiveTo = &activeTo
+		}
+		res.Products = append(res.Products, product)
+	}
+
+	c.JSON(http.StatusOK, res)
+}
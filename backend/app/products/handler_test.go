@@ -0,0 +1,75 @@
+package products
+
+import (
+	"backend-loan-pre-approval/pkg/money"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+func TestGetProducts_OnlyActive(t *testing.T) {
+	repo := NewMockRepo()
+	s := NewService(repo)
+	h := NewHandler(s)
+
+	repo.On("GetAllProducts", mock.Anything).Return([]ProductEntity{
+		{
+			Code:          "home",
+			Name:          "Home Purchase",
+			Currency:      "THB",
+			MinAmount:     100000,
+			MaxAmount:     500000000,
+			MinTermMonths: 12,
+			MaxTermMonths: 360,
+			MinRateBps:    325,
+			MaxRateBps:    650,
+			RuleOverrides: types.JSONText(`{"maxIncomeMultiple": 60}`),
+			ActiveFrom:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Code:          "wedding",
+			Name:          "Wedding Loan",
+			Currency:      "THB",
+			RuleOverrides: types.JSONText(`{}`),
+			ActiveFrom:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			ActiveTo:      sql.NullTime{Time: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	}, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/products", h.GetProducts)
+
+	req := httptest.NewRequest(http.MethodGet, "http://0.0.0.0/api/v1/products", nil)
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response GetProductsResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, 1, len(response.Products))
+	assert.Equal(t, "home", response.Products[0].Code)
+	assert.Equal(t, "3.25", response.Products[0].MinInterestRate)
+	assert.Equal(t, int64(60), *response.Products[0].RuleOverrides.MaxIncomeMultiple)
+}
+
+func Test_CheckAmount(t *testing.T) {
+	product := Product{Code: "home", MinAmount: money.FromMajor(1000, "THB"), MaxAmount: money.FromMajor(5000000, "THB")}
+
+	// Assert
+	assert.NilError(t, product.CheckAmount(money.FromMajor(1000, "THB")))
+	assert.ErrorContains(t, product.CheckAmount(money.FromMajor(999, "THB")), "must be between")
+	assert.ErrorContains(t, product.CheckAmount(money.FromMajor(5000, "USD")), "Loan amount currency must be THB")
+}
@@ -0,0 +1,112 @@
+package products
+
+import (
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/pkg/money"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Product is a loan product with its accepted amount and term ranges,
+// interest rate band and eligibility overrides.
+type Product struct {
+	Code          string
+	Name          string
+	MinAmount     money.Money
+	MaxAmount     money.Money
+	MinTermMonths int
+	MaxTermMonths int
+	MinRateBps    int
+	MaxRateBps    int
+	RuleOverrides eligibility.RuleOverrides
+	ActiveFrom    time.Time
+	ActiveTo      *time.Time
+}
+
+// IsActive reports whether the product can be applied for on the calendar
+// day of at. Both ends of the active range are inclusive.
+func (p Product) IsActive(at time.Time) bool {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	if day.Before(p.ActiveFrom) {
+		return false
+	}
+	return p.ActiveTo == nil || !day.After(*p.ActiveTo)
+}
+
+// CheckAmount validates amount against the product range. Amounts in another
+// currency than the product must be normalized by the caller first; an
+// unconverted amount is rejected rather than compared.
+func (p Product) CheckAmount(amount money.Money) error {
+	if amount.Currency != p.MinAmount.Currency {
+		return errors.New("Loan amount currency must be " + p.MinAmount.Currency)
+	}
+	if amount.LessThan(p.MinAmount) || amount.GreaterThan(p.MaxAmount) {
+		return errors.New("Loan amount for " + p.Code + " must be between " + p.MinAmount.String() + " and " + p.MaxAmount.String())
+	}
+	return nil
+}
+
+func (p Product) CheckTerm(months int) error {
+	if months < p.MinTermMonths || months > p.MaxTermMonths {
+		return fmt.Errorf("Loan term for %s must be between %d and %d months", p.Code, p.MinTermMonths, p.MaxTermMonths)
+	}
+	return nil
+}
+
+// TermOrDefault returns months, or the product's longest term when the
+// applicant did not ask for one.
+func (p Product) TermOrDefault(months int) int {
+	if months == 0 {
+		return p.MaxTermMonths
+	}
+	return months
+}
+
+// QuoteRateBps is the annual rate quoted for the product: the bottom of its
+// interest rate band.
+func (p Product) QuoteRateBps() int {
+	return p.MinRateBps
+}
+
+// ======== sample response ======== //
+// {
+// 	"products": [
+// 		{
+// 			"code": "home",
+// 			"name": "Home Purchase",
+// 			"minAmount": {"amount": "1000.00", "currency": "THB"},
+// 			"maxAmount": {"amount": "5000000.00", "currency": "THB"},
+// 			"minTermMonths": 12,
+// 			"maxTermMonths": 360,
+// 			"minInterestRate": "3.25",
+// 			"maxInterestRate": "6.50",
+// 			"ruleOverrides": {},
+// 			"activeFrom": "2025-01-01",
+// 			"activeTo": null
+// 		}
+// 	]
+// }
+
+type ProductResponse struct {
+	Code            string                    `json:"code"`
+	Name            string                    `json:"name"`
+	MinAmount       money.Money               `json:"minAmount"`
+	MaxAmount       money.Money               `json:"maxAmount"`
+	MinTermMonths   int                       `json:"minTermMonths"`
+	MaxTermMonths   int                       `json:"maxTermMonths"`
+	MinInterestRate string                    `json:"minInterestRate"`
+	MaxInterestRate string                    `json:"maxInterestRate"`
+	RuleOverrides   eligibility.RuleOverrides `json:"ruleOverrides"`
+	ActiveFrom      string                    `json:"activeFrom"`
+	ActiveTo        *string                   `json:"activeTo"`
+}
+
+type GetProductsResponse struct {
+	Products []ProductResponse `json:"products"`
+}
+
+// FormatRate renders basis points as an annual percentage, e.g. 325 as "3.25".
+func FormatRate(bps int) string {
+	return fmt.Sprintf("%d.%02d", bps/100, bps%100)
+}
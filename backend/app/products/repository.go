@@ -0,0 +1,51 @@
+package products
+
+import (
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetProduct(ctx context.Context, code string) (ProductEntity, error)
+	GetAllProducts(ctx context.Context) ([]ProductEntity, error)
+}
+
+type RepositoryImpl struct {
+	queries *store.Queries
+}
+
+func NewRepository(db *sqlx.DB) Repository {
+	return &RepositoryImpl{
+		queries: store.New(db),
+	}
+}
+
+func (r *RepositoryImpl) GetProduct(ctx context.Context, code string) (ProductEntity, error) {
+
+	product, err := r.queries.GetLoanProduct(ctx, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ProductEntity{}, errors.New(ErrNoRows)
+		}
+		log.Println("err: ", err)
+		return ProductEntity{}, err
+	}
+
+	return product, nil
+}
+
+func (r *RepositoryImpl) GetAllProducts(ctx context.Context) ([]ProductEntity, error) {
+
+	products, err := r.queries.ListLoanProducts(ctx)
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, err
+	}
+
+	return products, nil
+}
@@ -0,0 +1,5 @@
+package products
+
+import "backend-loan-pre-approval/pkg/store"
+
+type ProductEntity = store.LoanProduct
@@ -0,0 +1,27 @@
+package products
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepo struct {
+	mock.Mock
+}
+
+// Helper function to create a new repository with mocks
+func NewMockRepo() *MockRepo {
+
+	return &MockRepo{}
+}
+
+func (m *MockRepo) GetProduct(ctx context.Context, code string) (ProductEntity, error) {
+	args := m.Called(ctx, code)
+	return args.Get(0).(ProductEntity), args.Error(1)
+}
+
+func (m *MockRepo) GetAllProducts(ctx context.Context) ([]ProductEntity, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]ProductEntity), args.Error(1)
+}
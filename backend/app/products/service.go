@@ -0,0 +1,92 @@
+package products
+
+import (
+	"backend-loan-pre-approval/pkg/money"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+type Service interface {
+	GetActiveProducts(ctx context.Context, at time.Time) ([]Product, error)
+	GetActiveProduct(ctx context.Context, code string, at time.Time) (Product, error)
+}
+
+type ServiceImpl struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &ServiceImpl{
+		repository: repository,
+	}
+}
+
+func (s *ServiceImpl) GetActiveProducts(ctx context.Context, at time.Time) ([]Product, error) {
+
+	result, err := s.repository.GetAllProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []Product{}
+	for _, v := range result {
+		product, err := toProduct(v)
+		if err != nil {
+			return nil, err
+		}
+		if product.IsActive(at) {
+			res = append(res, product)
+		}
+	}
+
+	return res, nil
+}
+
+// GetActiveProduct returns an error starting with ErrReasonProductNotFound or
+// ErrReasonProductInactive when code cannot be applied for at the given time.
+func (s *ServiceImpl) GetActiveProduct(ctx context.Context, code string, at time.Time) (Product, error) {
+
+	result, err := s.repository.GetProduct(ctx, code)
+	if err != nil {
+		if strings.Contains(err.Error(), ErrNoRows) {
+			return Product{}, errors.New(ErrReasonProductNotFound + code)
+		}
+		return Product{}, err
+	}
+
+	product, err := toProduct(result)
+	if err != nil {
+		return Product{}, err
+	}
+	if !product.IsActive(at) {
+		return Product{}, errors.New(ErrReasonProductInactive + code)
+	}
+
+	return product, nil
+}
+
+func toProduct(e ProductEntity) (Product, error) {
+	product := Product{
+		Code:          e.Code,
+		Name:          e.Name,
+		MinAmount:     money.New(e.MinAmount, e.Currency),
+		MaxAmount:     money.New(e.MaxAmount, e.Currency),
+		MinTermMonths: e.MinTermMonths,
+		MaxTermMonths: e.MaxTermMonths,
+		MinRateBps:    e.MinRateBps,
+		MaxRateBps:    e.MaxRateBps,
+		ActiveFrom:    e.ActiveFrom,
+	}
+	if e.ActiveTo.Valid {
+		product.ActiveTo = &e.ActiveTo.Time
+	}
+	if len(e.RuleOverrides) > 0 {
+		if err := json.Unmarshal(e.RuleOverrides, &product.RuleOverrides); err != nil {
+			return Product{}, err
+		}
+	}
+	return product, nil
+}
@@ -0,0 +1,26 @@
+package products
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockService struct {
+	mock.Mock
+}
+
+func NewMockService() *MockService {
+	return &MockService{}
+}
+
+func (m *MockService) GetActiveProducts(ctx context.Context, at time.Time) ([]Product, error) {
+	args := m.Called(ctx, at)
+	return args.Get(0).([]Product), args.Error(1)
+}
+
+func (m *MockService) GetActiveProduct(ctx context.Context, code string, at time.Time) (Product, error) {
+	args := m.Called(ctx, code, at)
+	return args.Get(0).(Product), args.Error(1)
+}
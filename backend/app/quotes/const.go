@@ -0,0 +1,5 @@
+package quotes
+
+const (
+	MsgInvalidBody = "Invalid request body"
+)
@@ -0,0 +1,55 @@
+package quotes
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) CreateQuote(c *gin.Context) {
+
+	var req HttpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("err: ", err)
+		c.JSON(http.StatusBadRequest, HttpBadResponse{
+			Message: MsgInvalidBody,
+			Reason:  err.Error(),
+		})
+		return
+	}
+
+	if req.LoanPurpose == "" || req.LoanAmount.IsZero() {
+		c.JSON(http.StatusBadRequest, HttpBadResponse{
+			Message: MsgInvalidBody,
+			Reason:  "missing required fields: loanPurpose, loanAmount",
+		})
+		return
+	}
+
+	res, err := h.service.CreateQuote(c.Request.Context(), req)
+	if err != nil {
+		var validationErr ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, HttpBadResponse{
+				Message: MsgInvalidBody,
+				Reason:  err.Error(),
+			})
+			return
+		}
+		log.Println("err: ", err)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,83 @@
+package quotes
+
+import (
+	"backend-loan-pre-approval/app/products"
+	"backend-loan-pre-approval/pkg/money"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+func TestCreateQuote(t *testing.T) {
+	mockProducts := products.NewMockService()
+	s := NewService(mockProducts)
+	h := NewHandler(s)
+
+	mockProducts.On("GetActiveProduct", mock.Anything, "car", mock.Anything).Return(products.Product{
+		Code:          "car",
+		MinAmount:     money.FromMajor(1000, money.DefaultCurrency),
+		MaxAmount:     money.FromMajor(5000000, money.DefaultCurrency),
+		MinTermMonths: 12,
+		MaxTermMonths: 84,
+		MinRateBps:    250,
+		MaxRateBps:    700,
+	}, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/quotes", h.CreateQuote)
+
+	body := bytes.NewBufferString(`{"loanPurpose": "car", "loanAmount": 300000, "termMonths": 48}`)
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/quotes", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	var response HttpResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "2.50", response.AnnualInterestRate)
+	assert.Equal(t, 48, len(response.Payments))
+	assert.Equal(t, int64(0), response.Payments[47].Balance.Amount)
+}
+
+func TestCreateQuote_TermOutOfRange(t *testing.T) {
+	mockProducts := products.NewMockService()
+	s := NewService(mockProducts)
+	h := NewHandler(s)
+
+	mockProducts.On("GetActiveProduct", mock.Anything, "car", mock.Anything).Return(products.Product{
+		Code:          "car",
+		MinAmount:     money.FromMajor(1000, money.DefaultCurrency),
+		MaxAmount:     money.FromMajor(5000000, money.DefaultCurrency),
+		MinTermMonths: 12,
+		MaxTermMonths: 84,
+		MinRateBps:    250,
+		MaxRateBps:    700,
+	}, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/quotes", h.CreateQuote)
+
+	body := bytes.NewBufferString(`{"loanPurpose": "car", "loanAmount": 300000, "termMonths": 120}`)
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0/api/v1/quotes", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	messageExpected := `{"message":"Invalid request body","reason":"Loan term for car must be between 12 and 84 months"}`
+
+	// Assert
+	assert.Equal(t, messageExpected, resp.Body.String())
+}
@@ -0,0 +1,45 @@
+package quotes
+
+import (
+	"backend-loan-pre-approval/pkg/amortization"
+	"backend-loan-pre-approval/pkg/money"
+)
+
+// ======= sample request ======== //
+// {
+// 	"loanPurpose": "car",
+// 	"loanAmount": 300000,
+// 	"termMonths": 48
+// }
+
+type HttpRequest struct {
+	LoanPurpose string      `json:"loanPurpose"`
+	LoanAmount  money.Money `json:"loanAmount"`
+	TermMonths  int         `json:"termMonths"`
+}
+
+// ======== sample response ======== //
+// {
+// 	"loanPurpose": "car",
+// 	"annualInterestRate": "2.50",
+// 	"principal": {"amount": "300000.00", "currency": "THB"},
+// 	"annualRateBps": 250,
+// 	"termMonths": 48,
+// 	"monthlyInstallment": {"amount": "6574.21", "currency": "THB"},
+// 	"totalInterest": {"amount": "15562.09", "currency": "THB"},
+// 	"totalPayment": {"amount": "315562.09", "currency": "THB"},
+// 	"schedule": [
+// 		{"month": 1, "payment": {...}, "principal": {...}, "interest": {...}, "balance": {...}}
+// 	]
+// }
+
+type HttpResponse struct {
+	LoanPurpose        string `json:"loanPurpose"`
+	AnnualInterestRate string `json:"annualInterestRate"`
+	amortization.Schedule
+}
+
+type HttpBadResponse struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
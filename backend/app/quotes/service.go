@@ -0,0 +1,65 @@
+package quotes
+
+import (
+	"backend-loan-pre-approval/app/products"
+	"backend-loan-pre-approval/pkg/amortization"
+	"context"
+	"strings"
+	"time"
+)
+
+type Service interface {
+	CreateQuote(ctx context.Context, req HttpRequest) (HttpResponse, error)
+}
+
+// ValidationError is returned when the request cannot be quoted.
+type ValidationError struct {
+	Reason string
+}
+
+func (e ValidationError) Error() string {
+	return e.Reason
+}
+
+type ServiceImpl struct {
+	products products.Service
+}
+
+func NewService(products products.Service) Service {
+	return &ServiceImpl{
+		products: products,
+	}
+}
+
+// CreateQuote amortizes the requested amount at the product's quoted rate
+// without storing anything.
+func (s *ServiceImpl) CreateQuote(ctx context.Context, req HttpRequest) (HttpResponse, error) {
+
+	product, err := s.products.GetActiveProduct(ctx, req.LoanPurpose, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), products.ErrReasonProductNotFound) || strings.Contains(err.Error(), products.ErrReasonProductInactive) {
+			return HttpResponse{}, ValidationError{Reason: err.Error()}
+		}
+		return HttpResponse{}, err
+	}
+
+	if err := product.CheckAmount(req.LoanAmount); err != nil {
+		return HttpResponse{}, ValidationError{Reason: err.Error()}
+	}
+
+	termMonths := product.TermOrDefault(req.TermMonths)
+	if err := product.CheckTerm(termMonths); err != nil {
+		return HttpResponse{}, ValidationError{Reason: err.Error()}
+	}
+
+	schedule, err := amortization.Calculate(req.LoanAmount, product.QuoteRateBps(), termMonths)
+	if err != nil {
+		return HttpResponse{}, ValidationError{Reason: err.Error()}
+	}
+
+	return HttpResponse{
+		LoanPurpose:        product.Code,
+		AnnualInterestRate: products.FormatRate(product.QuoteRateBps()),
+		Schedule:           schedule,
+	}, nil
+}
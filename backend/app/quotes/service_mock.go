@@ -0,0 +1,20 @@
+package quotes
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockService struct {
+	mock.Mock
+}
+
+func NewMockService() *MockService {
+	return &MockService{}
+}
+
+func (m *MockService) CreateQuote(ctx context.Context, req HttpRequest) (HttpResponse, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(HttpResponse), args.Error(1)
+}
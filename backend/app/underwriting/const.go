@@ -0,0 +1,23 @@
+package underwriting
+
+import "errors"
+
+const (
+	MsgInvalidBody           = "Invalid request body"
+	MsgRequestFailed         = "Underwriting request failed"
+	MsgApprovedByUnderwriter = "Approved by underwriter"
+	MsgDeclinedByUnderwriter = "Declined by underwriter"
+)
+
+const (
+	DefaultLimit  = 20
+	MaxNotesRunes = 2000
+)
+
+var (
+	ErrOfficerRequired    = errors.New("An authenticated officer is required")
+	ErrItemNotFound       = errors.New("Application is not in the underwriting queue")
+	ErrItemClaimed        = errors.New("Application is claimed by another officer")
+	ErrClaimRequired      = errors.New("Application must be claimed by the deciding officer")
+	ErrItemAlreadyDecided = errors.New("Application has already been decided")
+)
@@ -0,0 +1,141 @@
+package underwriting
+
+import (
+	"backend-loan-pre-approval/pkg/officerauth"
+	"backend-loan-pre-approval/pkg/scorecard"
+	"backend-loan-pre-approval/pkg/store"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetQueue(c *gin.Context) {
+
+	status := c.Query("status")
+	if status != "" && status != store.UnderwritingPending && status != store.UnderwritingClaimed && status != store.UnderwritingDecided {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parameter status"})
+		return
+	}
+
+	limitInt, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(DefaultLimit)))
+	if err != nil || limitInt <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parameter limit"})
+		return
+	}
+
+	pageInt, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || pageInt <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parameter page"})
+		return
+	}
+
+	items, totalItems, err := h.service.GetQueue(c.Request.Context(), status, limitInt, pageInt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, GetQueueResponse{
+		Items:      items,
+		Page:       pageInt,
+		TotalPages: (totalItems + limitInt - 1) / limitInt,
+	})
+}
+
+// ClaimItem leases the item to the authenticated officer.
+func (h *Handler) ClaimItem(c *gin.Context) {
+
+	applicationId := c.Param("applicationId")
+
+	officerId := officerauth.OfficerId(c)
+	if officerId == "" {
+		respondError(c, ErrOfficerRequired)
+		return
+	}
+
+	item, err := h.service.ClaimItem(c.Request.Context(), applicationId, officerId)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *Handler) DecideItem(c *gin.Context) {
+
+	applicationId := c.Param("applicationId")
+
+	officerId := officerauth.OfficerId(c)
+	if officerId == "" {
+		respondError(c, ErrOfficerRequired)
+		return
+	}
+
+	var req DecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, HttpBadResponse{
+			Message: MsgInvalidBody,
+			Reason:  err.Error(),
+		})
+		return
+	}
+	req.OfficerId = officerId
+	req.Notes = strings.TrimSpace(req.Notes)
+
+	if err := checkDecisionRequest(req); err != nil {
+		c.JSON(http.StatusBadRequest, HttpBadResponse{
+			Message: MsgInvalidBody,
+			Reason:  err.Error(),
+		})
+		return
+	}
+
+	item, err := h.service.DecideItem(c.Request.Context(), applicationId, req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func checkDecisionRequest(req DecisionRequest) error {
+	if req.Outcome != scorecard.OutcomeApprove && req.Outcome != scorecard.OutcomeDecline {
+		return errors.New("Outcome must be one of: approve, decline")
+	}
+	if req.Notes == "" {
+		return errors.New("Notes are required for a manual decision")
+	}
+	if utf8.RuneCountInString(req.Notes) > MaxNotesRunes {
+		return errors.New("Notes must be at most 2000 characters")
+	}
+	return nil
+}
+
+func respondError(c *gin.Context, err error) {
+	log.Println("err: ", err)
+	switch {
+	case errors.Is(err, ErrOfficerRequired):
+		c.JSON(http.StatusUnauthorized, HttpBadResponse{Message: MsgRequestFailed, Reason: err.Error()})
+	case errors.Is(err, ErrItemNotFound):
+		c.JSON(http.StatusNotFound, HttpBadResponse{Message: MsgRequestFailed, Reason: err.Error()})
+	case errors.Is(err, ErrItemClaimed), errors.Is(err, ErrClaimRequired), errors.Is(err, ErrItemAlreadyDecided):
+		c.JSON(http.StatusConflict, HttpBadResponse{Message: MsgRequestFailed, Reason: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	}
+}
@@ -0,0 +1,166 @@
+package underwriting
+
+import (
+	"backend-loan-pre-approval/app/notifications"
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/officerauth"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestHandler(mockRepo *MockRepo) *Handler {
+	return newTestHandlerWithNotifier(mockRepo, nil)
+}
+
+func newTestHandlerWithNotifier(mockRepo *MockRepo, notifier notifications.Notifier) *Handler {
+	mockTx := database.NewMockTransactor()
+	mockTx.On("WithTx", mock.Anything).Return(nil)
+
+	s := NewService(mockRepo, mockTx, 30*time.Minute, notifier).(*ServiceImpl)
+	s.now = func() time.Time { return testNow }
+	return NewHandler(s)
+}
+
+func claimedItem(officer string, expiresAt time.Time) UnderwritingItemEntity {
+	return UnderwritingItemEntity{
+		ApplicationId:  "app-1",
+		Status:         "claimed",
+		ClaimedBy:      sql.NullString{String: officer, Valid: true},
+		ClaimExpiresAt: sql.NullTime{Time: expiresAt, Valid: true},
+		CreatedAt:      testNow.Add(-time.Hour),
+	}
+}
+
+// serve sends body as officer-17.
+func serve(h *Handler, path string, body string) *httptest.ResponseRecorder {
+	return serveAs(h, path, body, "token-17")
+}
+
+func serveAs(h *Handler, path string, body string, token string) *httptest.ResponseRecorder {
+	tokens, _ := officerauth.ParseTokens("officer-17:token-17,officer-9:token-9")
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	officer := r.Group("", officerauth.Required(tokens))
+	officer.POST("/api/v1/underwriting/queue/:applicationId/claim", h.ClaimItem)
+	officer.POST("/api/v1/underwriting/queue/:applicationId/decision", h.DecideItem)
+
+	req := httptest.NewRequest(http.MethodPost, "http://0.0.0.0"+path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	return resp
+}
+
+func Test_ClaimItem_SUCCESS(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockRepo.On("GetUnderwritingItemForUpdate", mock.Anything, "app-1").Return(UnderwritingItemEntity{ApplicationId: "app-1", Status: "pending"}, nil)
+	mockRepo.On("ClaimUnderwritingItem", mock.Anything, "app-1", "officer-17", testNow, testNow.Add(30*time.Minute)).
+		Return(claimedItem("officer-17", testNow.Add(30*time.Minute)), nil)
+
+	resp := serve(newTestHandler(mockRepo), "/api/v1/underwriting/queue/app-1/claim", "")
+
+	var response QueueItem
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "officer-17", response.ClaimedBy)
+	assert.Equal(t, testNow.Add(30*time.Minute), *response.ClaimExpiresAt)
+}
+
+func Test_ClaimItem_HeldByAnotherOfficer(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockRepo.On("GetUnderwritingItemForUpdate", mock.Anything, "app-1").Return(claimedItem("officer-9", testNow.Add(time.Minute)), nil)
+	mockRepo.On("ClaimUnderwritingItem", mock.Anything, "app-1", "officer-17", mock.Anything, mock.Anything).Return(UnderwritingItemEntity{}, ErrItemClaimed)
+
+	resp := serve(newTestHandler(mockRepo), "/api/v1/underwriting/queue/app-1/claim", "")
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
+
+func Test_DecideItem_SUCCESS(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockRepo.On("GetUnderwritingItemForUpdate", mock.Anything, "app-1").Return(claimedItem("officer-17", testNow.Add(time.Minute)), nil)
+	mockRepo.On("DecideUnderwritingItem", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("UpdateLoanApplicationOutcome", mock.Anything, mock.Anything).Return(nil)
+	mockNotifier := notifications.NewMockService()
+	mockNotifier.On("Notify", mock.Anything, "app-1", notifications.EventApproved).Return(nil)
+
+	resp := serve(newTestHandlerWithNotifier(mockRepo, mockNotifier), "/api/v1/underwriting/queue/app-1/decision",
+		`{"outcome": "approve", "notes": "  Payslips confirm income  "}`)
+
+	decided := mockRepo.Calls[1].Arguments.Get(1).(UnderwritingItemEntity)
+	application := mockRepo.Calls[2].Arguments.Get(1).(LoanApplicationEntity)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "decided", decided.Status)
+	assert.Equal(t, "Payslips confirm income", decided.Notes.String)
+	assert.Equal(t, true, application.Eligible.Bool)
+	assert.Equal(t, MsgApprovedByUnderwriter, application.Reason.String)
+	assert.Equal(t, "approve", application.Outcome.String)
+	mockNotifier.AssertExpectations(t)
+}
+
+func Test_DecideItem_ExpiredClaim(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockRepo.On("GetUnderwritingItemForUpdate", mock.Anything, "app-1").Return(claimedItem("officer-17", testNow.Add(-time.Minute)), nil)
+
+	resp := serve(newTestHandler(mockRepo), "/api/v1/underwriting/queue/app-1/decision",
+		`{"outcome": "decline", "notes": "Unverifiable employer"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	mockRepo.AssertNotCalled(t, "DecideUnderwritingItem", mock.Anything, mock.Anything)
+}
+
+func Test_DecideItem_NotesRequired(t *testing.T) {
+	mockRepo := NewMockRepo()
+
+	resp := serve(newTestHandler(mockRepo), "/api/v1/underwriting/queue/app-1/decision",
+		`{"outcome": "approve", "notes": "   "}`)
+
+	var response HttpBadResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &response); err != nil {
+		panic("error: " + err.Error())
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, "Notes are required for a manual decision", response.Reason)
+}
+
+func Test_DecideItem_ActsAsAuthenticatedOfficer(t *testing.T) {
+	mockRepo := NewMockRepo()
+	mockRepo.On("GetUnderwritingItemForUpdate", mock.Anything, "app-1").Return(claimedItem("officer-17", testNow.Add(time.Minute)), nil)
+	h := newTestHandler(mockRepo)
+
+	// officer-9 cannot decide officer-17's claim by naming them in the body.
+	impersonated := serveAs(h, "/api/v1/underwriting/queue/app-1/decision",
+		`{"officerId": "officer-17", "outcome": "approve", "notes": "Looks fine"}`, "token-9")
+	anonymous := serveAs(h, "/api/v1/underwriting/queue/app-1/claim", "", "")
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, impersonated.Code)
+	assert.Equal(t, http.StatusUnauthorized, anonymous.Code)
+	mockRepo.AssertNotCalled(t, "DecideUnderwritingItem", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "ClaimUnderwritingItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
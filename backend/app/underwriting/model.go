@@ -0,0 +1,49 @@
+package underwriting
+
+import "time"
+
+// ======== sample queue item ======== //
+// {
+// 	"applicationId": "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+// 	"status": "claimed",
+// 	"claimedBy": "officer-17",
+// 	"claimExpiresAt": "2025-07-19T20:04:56+07:00",
+// 	"createdAt": "2025-07-19T19:34:56+07:00"
+// }
+
+type QueueItem struct {
+	ApplicationId  string     `json:"applicationId"`
+	Status         string     `json:"status"`
+	ClaimedBy      string     `json:"claimedBy,omitempty"`
+	ClaimExpiresAt *time.Time `json:"claimExpiresAt,omitempty"`
+	DecidedBy      string     `json:"decidedBy,omitempty"`
+	DecidedOutcome string     `json:"decidedOutcome,omitempty"`
+	Notes          string     `json:"notes,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	DecidedAt      *time.Time `json:"decidedAt,omitempty"`
+}
+
+type GetQueueResponse struct {
+	Items      []QueueItem `json:"items"`
+	Page       int         `json:"page"`
+	TotalPages int         `json:"totalPages"`
+}
+
+// ======= sample decision request ======== //
+// {
+// 	"outcome": "approve",
+// 	"notes": "Payslips confirm stable income for 3 years"
+// }
+
+// DecisionRequest is decided by the authenticated officer; OfficerId is
+// never read from the body.
+type DecisionRequest struct {
+	OfficerId string `json:"-"`
+	Outcome   string `json:"outcome"`
+	Notes     string `json:"notes"`
+}
+
+type HttpBadResponse struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
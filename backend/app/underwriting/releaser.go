@@ -0,0 +1,37 @@
+package underwriting
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StartReleaser releases expired claims every interval until ctx is done.
+// Claiming already ignores expired leases, so this only keeps the queue's
+// status accurate for listing. An interval of 0 disables it.
+func StartReleaser(ctx context.Context, service Service, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				released, err := service.ReleaseExpiredClaims(ctx)
+				if err != nil {
+					log.Println("err: ", err)
+					continue
+				}
+				if released > 0 {
+					log.Printf("released %d expired underwriting claims", released)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,105 @@
+package underwriting
+
+import (
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetUnderwritingQueue(ctx context.Context, status string, limit int, offset int) ([]UnderwritingItemEntity, int, error)
+	GetUnderwritingItemForUpdate(ctx context.Context, applicationId string) (UnderwritingItemEntity, error)
+	ClaimUnderwritingItem(ctx context.Context, applicationId string, officer string, now time.Time, expiresAt time.Time) (UnderwritingItemEntity, error)
+	DecideUnderwritingItem(ctx context.Context, item UnderwritingItemEntity) error
+	UpdateLoanApplicationOutcome(ctx context.Context, LoanApplication LoanApplicationEntity) error
+	ReleaseExpiredClaims(ctx context.Context, now time.Time) (int64, error)
+}
+
+type RepositoryImpl struct {
+	queries *store.Queries
+}
+
+func NewRepository(db *sqlx.DB) Repository {
+	return &RepositoryImpl{
+		queries: store.New(db),
+	}
+}
+
+func (r *RepositoryImpl) GetUnderwritingQueue(ctx context.Context, status string, limit int, offset int) ([]UnderwritingItemEntity, int, error) {
+
+	items, total, err := r.queries.ListUnderwritingItems(ctx, store.ListUnderwritingItemsParams{
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		log.Println("err: ", err)
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+func (r *RepositoryImpl) GetUnderwritingItemForUpdate(ctx context.Context, applicationId string) (UnderwritingItemEntity, error) {
+
+	item, err := r.queries.GetUnderwritingItemForUpdate(ctx, applicationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UnderwritingItemEntity{}, ErrItemNotFound
+		}
+		log.Println("err: ", err)
+		return UnderwritingItemEntity{}, err
+	}
+
+	return item, nil
+}
+
+func (r *RepositoryImpl) ClaimUnderwritingItem(ctx context.Context, applicationId string, officer string, now time.Time, expiresAt time.Time) (UnderwritingItemEntity, error) {
+
+	item, err := r.queries.ClaimUnderwritingItem(ctx, applicationId, officer, now, expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UnderwritingItemEntity{}, ErrItemClaimed
+		}
+		log.Println("err: ", err)
+		return UnderwritingItemEntity{}, err
+	}
+
+	return item, nil
+}
+
+func (r *RepositoryImpl) DecideUnderwritingItem(ctx context.Context, item UnderwritingItemEntity) error {
+
+	if err := r.queries.DecideUnderwritingItem(ctx, item); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) UpdateLoanApplicationOutcome(ctx context.Context, LoanApplication LoanApplicationEntity) error {
+
+	if err := r.queries.UpdateLoanApplicationOutcome(ctx, LoanApplication); err != nil {
+		log.Println("err: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) ReleaseExpiredClaims(ctx context.Context, now time.Time) (int64, error) {
+
+	released, err := r.queries.ReleaseExpiredClaims(ctx, now)
+	if err != nil {
+		log.Println("err: ", err)
+		return 0, err
+	}
+
+	return released, nil
+}
@@ -0,0 +1,7 @@
+package underwriting
+
+import "backend-loan-pre-approval/pkg/store"
+
+type UnderwritingItemEntity = store.UnderwritingItem
+
+type LoanApplicationEntity = store.LoanApplication
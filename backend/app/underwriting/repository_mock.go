@@ -0,0 +1,48 @@
+package underwriting
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepo struct {
+	mock.Mock
+}
+
+// Helper function to create a new repository with mocks
+func NewMockRepo() *MockRepo {
+
+	return &MockRepo{}
+}
+
+func (m *MockRepo) GetUnderwritingQueue(ctx context.Context, status string, limit int, offset int) ([]UnderwritingItemEntity, int, error) {
+	args := m.Called(ctx, status, limit, offset)
+	return args.Get(0).([]UnderwritingItemEntity), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepo) GetUnderwritingItemForUpdate(ctx context.Context, applicationId string) (UnderwritingItemEntity, error) {
+	args := m.Called(ctx, applicationId)
+	return args.Get(0).(UnderwritingItemEntity), args.Error(1)
+}
+
+func (m *MockRepo) ClaimUnderwritingItem(ctx context.Context, applicationId string, officer string, now time.Time, expiresAt time.Time) (UnderwritingItemEntity, error) {
+	args := m.Called(ctx, applicationId, officer, now, expiresAt)
+	return args.Get(0).(UnderwritingItemEntity), args.Error(1)
+}
+
+func (m *MockRepo) DecideUnderwritingItem(ctx context.Context, item UnderwritingItemEntity) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockRepo) UpdateLoanApplicationOutcome(ctx context.Context, LoanApplication LoanApplicationEntity) error {
+	args := m.Called(ctx, LoanApplication)
+	return args.Error(0)
+}
+
+func (m *MockRepo) ReleaseExpiredClaims(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,165 @@
+package underwriting
+
+import (
+	"backend-loan-pre-approval/app/notifications"
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/scorecard"
+	"backend-loan-pre-approval/pkg/store"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type Service interface {
+	GetQueue(ctx context.Context, status string, limit int, page int) ([]QueueItem, int, error)
+	ClaimItem(ctx context.Context, applicationId string, officerId string) (QueueItem, error)
+	DecideItem(ctx context.Context, applicationId string, req DecisionRequest) (QueueItem, error)
+	ReleaseExpiredClaims(ctx context.Context) (int64, error)
+}
+
+type ServiceImpl struct {
+	repository Repository
+	transactor database.Transactor
+	lease      time.Duration
+	notifier   notifications.Notifier
+	now        func() time.Time
+}
+
+// NewService builds the underwriting service. A claim holds an item for
+// lease; after that another officer can claim it. notifier may be nil, in
+// which case applicants are not told of decisions.
+func NewService(repository Repository, transactor database.Transactor, lease time.Duration, notifier notifications.Notifier) Service {
+	return &ServiceImpl{
+		repository: repository,
+		transactor: transactor,
+		lease:      lease,
+		notifier:   notifier,
+		now:        time.Now,
+	}
+}
+
+// GetQueue lists one page of the queue, oldest first. page starts at 1.
+func (s *ServiceImpl) GetQueue(ctx context.Context, status string, limit int, page int) ([]QueueItem, int, error) {
+
+	result, totalItems, err := s.repository.GetUnderwritingQueue(ctx, status, limit, (page-1)*limit)
+	if err != nil {
+		return []QueueItem{}, 0, err
+	}
+
+	res := []QueueItem{}
+	for _, v := range result {
+		res = append(res, toQueueItem(v))
+	}
+
+	return res, totalItems, nil
+}
+
+// ClaimItem leases the item to officerId, or renews the lease if the officer
+// already holds it.
+func (s *ServiceImpl) ClaimItem(ctx context.Context, applicationId string, officerId string) (QueueItem, error) {
+
+	var res QueueItem
+	err := s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		item, err := s.repository.GetUnderwritingItemForUpdate(ctx, applicationId)
+		if err != nil {
+			return err
+		}
+		if item.Status == store.UnderwritingDecided {
+			return ErrItemAlreadyDecided
+		}
+
+		now := s.now()
+		claimed, err := s.repository.ClaimUnderwritingItem(ctx, applicationId, officerId, now, now.Add(s.lease))
+		if err != nil {
+			return err
+		}
+		res = toQueueItem(claimed)
+		return nil
+	})
+	if err != nil {
+		return QueueItem{}, err
+	}
+
+	return res, nil
+}
+
+// DecideItem records the officer's override on the queue item and the
+// application, and tells the applicant. The officer must hold an unexpired
+// claim on the item.
+func (s *ServiceImpl) DecideItem(ctx context.Context, applicationId string, req DecisionRequest) (QueueItem, error) {
+
+	var res QueueItem
+	err := s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		item, err := s.repository.GetUnderwritingItemForUpdate(ctx, applicationId)
+		if err != nil {
+			return err
+		}
+		if item.Status == store.UnderwritingDecided {
+			return ErrItemAlreadyDecided
+		}
+
+		now := s.now()
+		if item.Status != store.UnderwritingClaimed || item.ClaimedBy.String != req.OfficerId || !item.ClaimExpiresAt.Time.After(now) {
+			return ErrClaimRequired
+		}
+
+		item.Status = store.UnderwritingDecided
+		item.DecidedBy = sql.NullString{String: req.OfficerId, Valid: true}
+		item.DecidedOutcome = sql.NullString{String: req.Outcome, Valid: true}
+		item.Notes = sql.NullString{String: req.Notes, Valid: true}
+		item.DecidedAt = sql.NullTime{Time: now, Valid: true}
+		if err := s.repository.DecideUnderwritingItem(ctx, item); err != nil {
+			return err
+		}
+
+		reason := MsgDeclinedByUnderwriter
+		if req.Outcome == scorecard.OutcomeApprove {
+			reason = MsgApprovedByUnderwriter
+		}
+		if err := s.repository.UpdateLoanApplicationOutcome(ctx, LoanApplicationEntity{
+			ApplicationId: applicationId,
+			Eligible:      sql.NullBool{Bool: req.Outcome == scorecard.OutcomeApprove, Valid: true},
+			Reason:        sql.NullString{String: reason, Valid: true},
+			Outcome:       sql.NullString{String: req.Outcome, Valid: true},
+		}); err != nil {
+			return err
+		}
+		if s.notifier != nil {
+			if err := s.notifier.Notify(ctx, applicationId, notifications.EventForOutcome(req.Outcome)); err != nil {
+				return err
+			}
+		}
+
+		res = toQueueItem(item)
+		return nil
+	})
+	if err != nil {
+		return QueueItem{}, err
+	}
+
+	return res, nil
+}
+
+// ReleaseExpiredClaims returns items whose lease has run out to pending.
+func (s *ServiceImpl) ReleaseExpiredClaims(ctx context.Context) (int64, error) {
+	return s.repository.ReleaseExpiredClaims(ctx, s.now())
+}
+
+func toQueueItem(e UnderwritingItemEntity) QueueItem {
+	item := QueueItem{
+		ApplicationId:  e.ApplicationId,
+		Status:         e.Status,
+		ClaimedBy:      e.ClaimedBy.String,
+		DecidedBy:      e.DecidedBy.String,
+		DecidedOutcome: e.DecidedOutcome.String,
+		Notes:          e.Notes.String,
+		CreatedAt:      e.CreatedAt,
+	}
+	if e.ClaimExpiresAt.Valid {
+		item.ClaimExpiresAt = &e.ClaimExpiresAt.Time
+	}
+	if e.DecidedAt.Valid {
+		item.DecidedAt = &e.DecidedAt.Time
+	}
+	return item
+}
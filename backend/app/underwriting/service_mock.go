@@ -0,0 +1,35 @@
+package underwriting
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockService struct {
+	mock.Mock
+}
+
+func NewMockService() *MockService {
+	return &MockService{}
+}
+
+func (m *MockService) GetQueue(ctx context.Context, status string, limit int, page int) ([]QueueItem, int, error) {
+	args := m.Called(ctx, status, limit, page)
+	return args.Get(0).([]QueueItem), args.Int(1), args.Error(2)
+}
+
+func (m *MockService) ClaimItem(ctx context.Context, applicationId string, officerId string) (QueueItem, error) {
+	args := m.Called(ctx, applicationId, officerId)
+	return args.Get(0).(QueueItem), args.Error(1)
+}
+
+func (m *MockService) DecideItem(ctx context.Context, applicationId string, req DecisionRequest) (QueueItem, error) {
+	args := m.Called(ctx, applicationId, req)
+	return args.Get(0).(QueueItem), args.Error(1)
+}
+
+func (m *MockService) ReleaseExpiredClaims(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,25 @@
+// Command bureau-stub serves canned credit bureau reports from a stub file
+// over the HTTP provider protocol, for running the backend locally with
+// credit_bureau.provider set to "http".
+package main
+
+import (
+	"backend-loan-pre-approval/pkg/creditbureau"
+	"flag"
+	"log"
+	"net/http"
+)
+
+func main() {
+	addr := flag.String("addr", ":30091", "listen address")
+	file := flag.String("file", "configs/credit_bureau_stub.json", "stub reports file")
+	flag.Parse()
+
+	bureau, err := creditbureau.LoadFile(*file)
+	if err != nil {
+		log.Fatalf("Error reading stub file: %v", err)
+	}
+
+	log.Printf("credit bureau stub listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, creditbureau.NewStubHandler(bureau)))
+}
@@ -1,12 +1,17 @@
 package main
 
 import (
+	"backend-loan-pre-approval/app/backtest"
 	"backend-loan-pre-approval/configs"
+	"backend-loan-pre-approval/migrations"
+	"backend-loan-pre-approval/pkg/birthdate"
 	"backend-loan-pre-approval/pkg/database"
 	"backend-loan-pre-approval/routes"
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,6 +23,12 @@ func main() {
 		log.Fatalf("Error reading config file: %v", err)
 	}
 
+	if appconf.Eligibility.Timezone != "" {
+		if err := birthdate.SetLocation(appconf.Eligibility.Timezone); err != nil {
+			log.Fatalf("Error reading config file: %v", err)
+		}
+	}
+
 	db, err := database.ConnectPostgres(
 		appconf.Database.Host,
 		appconf.Database.Port,
@@ -29,12 +40,24 @@ func main() {
 	}
 	defer db.Close()
 
+	if err := database.Migrate(context.Background(), db, migrations.FS); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "backtest" {
+		if err := backtest.RunCommand(context.Background(), os.Args[2:], db, appconf, os.Stdout); err != nil {
+			log.Fatalf("Backtest failed: %v", err)
+		}
+		return
+	}
+
 	r := gin.Default()
 
 	r.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, If-Match, X-National-Id")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
@@ -43,6 +66,8 @@ func main() {
 
 		c.Next()
 	})
-	routes.SetupRoutes(r, db)
+	if err := routes.SetupRoutes(r, db, appconf); err != nil {
+		log.Fatalf("Failed to set up routes: %v", err)
+	}
 	r.Run(fmt.Sprintf(":%d", appconf.App.Port))
 }
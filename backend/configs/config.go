@@ -55,5 +55,45 @@ func ReadConfig(pathConfigFile string) (AppConfig, error) {
 		appConfig.Database.DBName = os.Getenv("DB_NAME")
 	}
 
+	if os.Getenv("NATIONAL_ID_ENCRYPTION_KEY") != "" {
+		appConfig.NationalId.EncryptionKey = os.Getenv("NATIONAL_ID_ENCRYPTION_KEY")
+	}
+
+	if os.Getenv("NATIONAL_ID_INDEX_KEY") != "" {
+		appConfig.NationalId.IndexKey = os.Getenv("NATIONAL_ID_INDEX_KEY")
+	}
+
+	if os.Getenv("OFFICER_TOKENS") != "" {
+		appConfig.Underwriting.OfficerTokens = os.Getenv("OFFICER_TOKENS")
+	}
+
+	if os.Getenv("DOCUMENTS_S3_ACCESS_KEY") != "" {
+		appConfig.Documents.S3.AccessKey = os.Getenv("DOCUMENTS_S3_ACCESS_KEY")
+	}
+
+	if os.Getenv("DOCUMENTS_S3_SECRET_KEY") != "" {
+		appConfig.Documents.S3.SecretKey = os.Getenv("DOCUMENTS_S3_SECRET_KEY")
+	}
+
+	if os.Getenv("CLAMD_ADDRESS") != "" {
+		appConfig.Documents.Scanner.Address = os.Getenv("CLAMD_ADDRESS")
+	}
+
+	if os.Getenv("SMTP_ADDRESS") != "" {
+		appConfig.Notifications.Email.Address = os.Getenv("SMTP_ADDRESS")
+	}
+
+	if os.Getenv("SMTP_USERNAME") != "" {
+		appConfig.Notifications.Email.Username = os.Getenv("SMTP_USERNAME")
+	}
+
+	if os.Getenv("SMTP_PASSWORD") != "" {
+		appConfig.Notifications.Email.Password = os.Getenv("SMTP_PASSWORD")
+	}
+
+	if os.Getenv("SMS_API_KEY") != "" {
+		appConfig.Notifications.SMS.APIKey = os.Getenv("SMS_API_KEY")
+	}
+
 	return appConfig, nil
 }
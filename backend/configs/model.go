@@ -1,5 +1,10 @@
 package configs
 
+import (
+	"backend-loan-pre-approval/app/eligibility"
+	"time"
+)
+
 type AppConfig struct {
 	App struct {
 		Name string `mapstructure:"name"`
@@ -13,4 +18,132 @@ type AppConfig struct {
 		Password string `mapstructure:"password"`
 		DBName   string `mapstructure:"dbname"`
 	} `mapstructure:"database"`
+
+	FX struct {
+		BaseCurrency string `mapstructure:"base_currency"`
+		RatesFile    string `mapstructure:"rates_file"`
+	} `mapstructure:"fx"`
+
+	CreditBureau struct {
+		// Provider is "file", "http" or empty to skip the bureau.
+		Provider         string        `mapstructure:"provider"`
+		File             string        `mapstructure:"file"`
+		URL              string        `mapstructure:"url"`
+		Timeout          time.Duration `mapstructure:"timeout"`
+		MaxRetries       int           `mapstructure:"max_retries"`
+		RetryBackoff     time.Duration `mapstructure:"retry_backoff"`
+		FailureThreshold int           `mapstructure:"failure_threshold"`
+		OpenDuration     time.Duration `mapstructure:"open_duration"`
+	} `mapstructure:"credit_bureau"`
+
+	Scorecard struct {
+		// File is the scorecard definition; empty approves on the rules
+		// alone.
+		File string `mapstructure:"file"`
+	} `mapstructure:"scorecard"`
+
+	Underwriting struct {
+		ClaimLease      time.Duration `mapstructure:"claim_lease"`
+		ReleaseInterval time.Duration `mapstructure:"release_interval"`
+		// OfficerTokens issues bearer tokens to officers as
+		// "officer-17:token,...", overridden by OFFICER_TOKENS. Officer
+		// routes reject every request while it is empty.
+		OfficerTokens string `mapstructure:"officer_tokens"`
+	} `mapstructure:"underwriting"`
+
+	Experiment struct {
+		// ChallengerRuleset is the ruleset trialled against the champion;
+		// empty runs no experiment.
+		ChallengerRuleset string `mapstructure:"challenger_ruleset"`
+		ChallengerPercent int    `mapstructure:"challenger_percent"`
+	} `mapstructure:"experiment"`
+
+	Eligibility struct {
+		// Timezone is the IANA zone, e.g. "Asia/Bangkok", on whose
+		// calendar applicant ages are worked out.
+		Timezone string `mapstructure:"timezone"`
+		// Employment holds the rules of each employment type, keyed by
+		// type; types left out have none.
+		Employment map[string]eligibility.EmploymentRule `mapstructure:"employment"`
+	} `mapstructure:"eligibility"`
+
+	Phone struct {
+		// DefaultRegion is the ISO 3166 region of numbers written without
+		// a country code.
+		DefaultRegion string `mapstructure:"default_region"`
+	} `mapstructure:"phone"`
+
+	Email struct {
+		// DisposableDomainsFile lists blocked email domains; empty allows
+		// every domain.
+		DisposableDomainsFile string `mapstructure:"disposable_domains_file"`
+	} `mapstructure:"email"`
+
+	NationalId struct {
+		// Required rejects submissions without a national ID; until then
+		// the field is optional.
+		Required bool `mapstructure:"required"`
+		// Keys are base64-encoded 32-byte secrets, overridden by
+		// NATIONAL_ID_ENCRYPTION_KEY and NATIONAL_ID_INDEX_KEY.
+		EncryptionKey string `mapstructure:"encryption_key"`
+		IndexKey      string `mapstructure:"index_key"`
+		// AllowDevKeys accepts the development keys committed in
+		// config.yaml. Only local development sets it.
+		AllowDevKeys bool `mapstructure:"allow_dev_keys"`
+	} `mapstructure:"national_id"`
+
+	Documents struct {
+		MaxSizeBytes int64 `mapstructure:"max_size_bytes"`
+		// Storage is "file", which keeps documents under Dir, or "s3".
+		Storage string `mapstructure:"storage"`
+		Dir     string `mapstructure:"dir"`
+		S3      struct {
+			Endpoint string `mapstructure:"endpoint"`
+			Bucket   string `mapstructure:"bucket"`
+			Region   string `mapstructure:"region"`
+			// Keys are overridden by DOCUMENTS_S3_ACCESS_KEY and
+			// DOCUMENTS_S3_SECRET_KEY.
+			AccessKey string `mapstructure:"access_key"`
+			SecretKey string `mapstructure:"secret_key"`
+			PathStyle bool   `mapstructure:"path_style"`
+		} `mapstructure:"s3"`
+		Scanner struct {
+			// Provider is "clamd", "fake" or empty, which leaves
+			// documents quarantined.
+			Provider string `mapstructure:"provider"`
+			// Address is "unix://{path}" or "tcp://{host}:{port}",
+			// overridden by CLAMD_ADDRESS.
+			Address  string        `mapstructure:"address"`
+			Timeout  time.Duration `mapstructure:"timeout"`
+			Interval time.Duration `mapstructure:"interval"`
+		} `mapstructure:"scanner"`
+	} `mapstructure:"documents"`
+
+	Notifications struct {
+		// DefaultLocale is "th" or "en", for applicants who chose neither.
+		DefaultLocale string `mapstructure:"default_locale"`
+		// Interval is how often due notifications are sent; 0 leaves
+		// them pending.
+		Interval time.Duration `mapstructure:"interval"`
+		Email    struct {
+			// Provider is "smtp" or empty to send no email.
+			Provider string `mapstructure:"provider"`
+			// Address is the SMTP server's "{host}:{port}"; no email is sent
+			// while it is empty. Address, Username and Password are
+			// overridden by SMTP_ADDRESS, SMTP_USERNAME and SMTP_PASSWORD.
+			Address  string        `mapstructure:"address"`
+			Username string        `mapstructure:"username"`
+			Password string        `mapstructure:"password"`
+			From     string        `mapstructure:"from"`
+			Timeout  time.Duration `mapstructure:"timeout"`
+		} `mapstructure:"email"`
+		SMS struct {
+			// Provider is "http", "log", which only writes messages to
+			// the log, or empty to send no SMS.
+			Provider string `mapstructure:"provider"`
+			URL      string `mapstructure:"url"`
+			// APIKey is overridden by SMS_API_KEY.
+			APIKey string `mapstructure:"api_key"`
+		} `mapstructure:"sms"`
+	} `mapstructure:"notifications"`
 }
@@ -0,0 +1,7 @@
+-- Amounts were stored as whole baht; store them in satang with an explicit
+-- ISO 4217 currency instead.
+ALTER TABLE loan_applications
+    ALTER COLUMN monthly_income TYPE BIGINT USING monthly_income::BIGINT * 100,
+    ALTER COLUMN loan_amount TYPE BIGINT USING loan_amount::BIGINT * 100,
+    ADD COLUMN monthly_income_currency CHAR(3) NOT NULL DEFAULT 'THB',
+    ADD COLUMN loan_amount_currency CHAR(3) NOT NULL DEFAULT 'THB';
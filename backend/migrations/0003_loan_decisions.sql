@@ -0,0 +1,7 @@
+-- Persist the eligibility decision, including the exchange rates it used,
+-- instead of recomputing it on every read. Rows created before this
+-- migration keep NULL and are re-evaluated on read.
+ALTER TABLE loan_applications
+    ADD COLUMN eligible BOOLEAN,
+    ADD COLUMN reason TEXT,
+    ADD COLUMN decision JSONB;
@@ -0,0 +1,28 @@
+-- Loan product catalogue. Amounts are minor units of currency, interest
+-- rates are basis points per year and rule_overrides replaces individual
+-- eligibility.Ruleset thresholds for the product.
+CREATE TABLE IF NOT EXISTS loan_products (
+    code VARCHAR(100) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    min_amount BIGINT NOT NULL,
+    max_amount BIGINT NOT NULL,
+    min_term_months INT NOT NULL,
+    max_term_months INT NOT NULL,
+    min_rate_bps INT NOT NULL,
+    max_rate_bps INT NOT NULL,
+    rule_overrides JSONB NOT NULL DEFAULT '{}',
+    active_from DATE NOT NULL,
+    active_to DATE
+);
+
+INSERT INTO loan_products (
+    code, name, currency, min_amount, max_amount, min_term_months, max_term_months,
+    min_rate_bps, max_rate_bps, rule_overrides, active_from
+) VALUES
+    ('home', 'Home Purchase', 'THB', 100000, 500000000, 12, 360, 325, 650, '{}', '2025-01-01'),
+    ('car', 'Car Loan', 'THB', 100000, 500000000, 12, 84, 250, 700, '{}', '2025-01-01'),
+    ('education', 'Education', 'THB', 100000, 200000000, 6, 120, 100, 400, '{}', '2025-01-01'),
+    ('personal', 'Personal Loan', 'THB', 100000, 100000000, 6, 60, 900, 2500, '{}', '2025-01-01'),
+    ('business', 'Business Loan', 'THB', 100000, 500000000, 12, 120, 500, 1200, '{}', '2025-01-01')
+ON CONFLICT (code) DO NOTHING;
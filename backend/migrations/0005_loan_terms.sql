@@ -0,0 +1,5 @@
+-- Requested term and quoted annual rate (basis points). 0 for applications
+-- stored before terms were captured.
+ALTER TABLE loan_applications
+    ADD COLUMN term_months INT NOT NULL DEFAULT 0,
+    ADD COLUMN interest_rate_bps INT NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- Existing monthly debt payments declared by the applicant.
+CREATE TABLE IF NOT EXISTS loan_obligations (
+    id BIGSERIAL PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES loan_applications (application_id) ON DELETE CASCADE,
+    obligation_type VARCHAR(50) NOT NULL,
+    monthly_payment BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_loan_obligations_application_id ON loan_obligations (application_id);
@@ -0,0 +1,4 @@
+-- Counter-offer made to a declined applicant and when it was accepted.
+ALTER TABLE loan_applications
+    ADD COLUMN offer JSONB,
+    ADD COLUMN offer_accepted_at TIMESTAMPTZ;
@@ -0,0 +1,10 @@
+-- Credit bureau report fetched for each application. report holds the
+-- provider's raw response and is NULL when the bureau was unavailable.
+CREATE TABLE IF NOT EXISTS credit_reports (
+    application_id UUID PRIMARY KEY REFERENCES loan_applications (application_id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    score INT,
+    report JSONB,
+    retrieved_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,6 @@
+-- Scorecard result, NULL for applications decided before scoring or
+-- without a scorecard configured. outcome is approve, refer or decline.
+ALTER TABLE loan_applications
+    ADD COLUMN IF NOT EXISTS outcome VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS risk_score INT,
+    ADD COLUMN IF NOT EXISTS risk_grade VARCHAR(5);
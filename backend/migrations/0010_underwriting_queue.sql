@@ -0,0 +1,16 @@
+-- Referred applications awaiting a manual decision. A claim is a lease:
+-- once claim_expires_at passes, the item can be claimed by someone else
+-- and is put back to pending by the releaser.
+CREATE TABLE IF NOT EXISTS underwriting_queue (
+    application_id UUID PRIMARY KEY REFERENCES loan_applications (application_id) ON DELETE CASCADE,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    claimed_by VARCHAR(100),
+    claim_expires_at TIMESTAMPTZ,
+    decided_by VARCHAR(100),
+    decided_outcome VARCHAR(10),
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    decided_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_underwriting_queue_status ON underwriting_queue (status, created_at);
@@ -0,0 +1,15 @@
+-- Champion/challenger assignment per application. The champion decision is
+-- the one on loan_applications; the challenger's is kept here for
+-- comparison and never shown to the applicant.
+CREATE TABLE IF NOT EXISTS loan_experiments (
+    application_id UUID PRIMARY KEY REFERENCES loan_applications (application_id) ON DELETE CASCADE,
+    experiment VARCHAR(100) NOT NULL,
+    arm VARCHAR(20) NOT NULL,
+    challenger_eligible BOOLEAN NOT NULL,
+    challenger_outcome VARCHAR(10) NOT NULL,
+    challenger_reason TEXT NOT NULL,
+    challenger_decision JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_loan_experiments_experiment ON loan_experiments (experiment, arm);
@@ -0,0 +1,6 @@
+-- Applications can be saved as drafts and edited before they are submitted
+-- for a decision. version is bumped on every write and serves as the ETag
+-- for optimistic concurrency.
+ALTER TABLE loan_applications
+    ADD COLUMN status VARCHAR(10) NOT NULL DEFAULT 'submitted',
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
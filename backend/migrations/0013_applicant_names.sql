@@ -0,0 +1,16 @@
+-- Given and family names in Thai and Latin script, stored NFC-normalized.
+-- full_name_key is the case-folded, whitespace-collapsed full name used for
+-- matching applicants; existing rows are backfilled with the closest SQL
+-- equivalent.
+ALTER TABLE loan_applications
+    ADD COLUMN given_name_th VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN family_name_th VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN given_name_en VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN family_name_en VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN full_name_key TEXT NOT NULL DEFAULT '';
+
+UPDATE loan_applications
+    SET full_name_key = lower(regexp_replace(btrim(normalize(full_name, NFC)), '\s+', ' ', 'g'))
+    WHERE full_name_key = '';
+
+CREATE INDEX IF NOT EXISTS idx_loan_applications_full_name_key ON loan_applications (full_name_key);
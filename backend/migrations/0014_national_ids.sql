@@ -0,0 +1,7 @@
+-- Thai national ID, AES-GCM encrypted. national_id_hash is a keyed HMAC of
+-- the normalized ID and is the lookup key for an applicant's applications.
+ALTER TABLE loan_applications
+    ADD COLUMN national_id_encrypted BYTEA,
+    ADD COLUMN national_id_hash CHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_loan_applications_national_id_hash ON loan_applications (national_id_hash);
@@ -0,0 +1,13 @@
+-- phone_number keeps the number as the applicant wrote it, for display.
+-- phone_e164 is the normalized form used for matching, with its region and
+-- line type. Existing rows were all 10-digit Thai numbers.
+ALTER TABLE loan_applications
+    ADD COLUMN phone_e164 VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN phone_region CHAR(2) NOT NULL DEFAULT '',
+    ADD COLUMN phone_type VARCHAR(20) NOT NULL DEFAULT '';
+
+UPDATE loan_applications
+    SET phone_e164 = '+66' || substr(phone_number, 2), phone_region = 'TH'
+    WHERE phone_e164 = '' AND phone_number ~ '^0[0-9]{9}$';
+
+CREATE INDEX IF NOT EXISTS idx_loan_applications_phone_e164 ON loan_applications (phone_e164);
@@ -0,0 +1,5 @@
+-- date_of_birth replaces the self-reported age, which goes stale. age keeps
+-- the age at application time for reporting and for older rows, which have
+-- no date of birth.
+ALTER TABLE loan_applications
+    ADD COLUMN date_of_birth DATE;
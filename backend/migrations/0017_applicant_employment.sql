@@ -0,0 +1,7 @@
+-- Employment and income source as stated by the applicant. Rows stored
+-- before it was captured, and applicants who gave none, keep the defaults.
+ALTER TABLE loan_applications
+    ADD COLUMN employment_type VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN employer_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN employment_tenure_months INT NOT NULL DEFAULT 0,
+    ADD COLUMN income_frequency VARCHAR(20) NOT NULL DEFAULT '';
@@ -0,0 +1,27 @@
+-- Co-borrowers and guarantors of an application, with the same personal
+-- columns as loan_applications. Only co-borrowers' income counts towards
+-- eligibility.
+CREATE TABLE IF NOT EXISTS loan_co_applicants (
+    id BIGSERIAL PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES loan_applications (application_id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    full_name VARCHAR(255) NOT NULL,
+    given_name_th VARCHAR(100) NOT NULL DEFAULT '',
+    family_name_th VARCHAR(100) NOT NULL DEFAULT '',
+    given_name_en VARCHAR(100) NOT NULL DEFAULT '',
+    family_name_en VARCHAR(100) NOT NULL DEFAULT '',
+    full_name_key TEXT NOT NULL DEFAULT '',
+    date_of_birth DATE,
+    phone_number VARCHAR(20) NOT NULL,
+    phone_e164 VARCHAR(16) NOT NULL DEFAULT '',
+    phone_region CHAR(2) NOT NULL DEFAULT '',
+    phone_type VARCHAR(20) NOT NULL DEFAULT '',
+    email VARCHAR(255) NOT NULL,
+    national_id_encrypted BYTEA,
+    national_id_hash CHAR(64),
+    monthly_income BIGINT NOT NULL DEFAULT 0,
+    monthly_income_currency CHAR(3) NOT NULL DEFAULT 'THB'
+);
+
+CREATE INDEX IF NOT EXISTS idx_loan_co_applicants_application_id ON loan_co_applicants (application_id);
+CREATE INDEX IF NOT EXISTS idx_loan_co_applicants_national_id_hash ON loan_co_applicants (national_id_hash);
@@ -0,0 +1,15 @@
+-- Supporting documents such as payslips and ID copies. The file itself is
+-- kept in the blob store under storage_key; identical content is stored
+-- once and uploaded at most once per application.
+CREATE TABLE IF NOT EXISTS loan_documents (
+    document_id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES loan_applications (application_id) ON DELETE CASCADE,
+    document_type VARCHAR(30) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    storage_key TEXT NOT NULL,
+    uploaded_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (application_id, sha256)
+);
@@ -0,0 +1,9 @@
+-- Documents are quarantined until the virus scanner has passed them, and
+-- only clean documents can be downloaded. Documents uploaded before
+-- scanning existed are quarantined and scanned too.
+ALTER TABLE loan_documents
+    ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'quarantined',
+    ADD COLUMN IF NOT EXISTS scan_signature VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS scanned_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_loan_documents_quarantined ON loan_documents (uploaded_at) WHERE scan_status = 'quarantined';
@@ -0,0 +1,40 @@
+-- locale is the language the applicant is written to in, "th" or "en".
+-- Older applications have none and get notifications.default_locale.
+ALTER TABLE loan_applications
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(5) NOT NULL DEFAULT '';
+
+-- Notifications are rendered and stored together with the decision or
+-- status change they announce, then sent by the dispatcher. A notification
+-- is retried until it is sent or has used up its attempts.
+CREATE TABLE IF NOT EXISTS loan_notifications (
+    notification_id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES loan_applications (application_id) ON DELETE CASCADE,
+    event VARCHAR(30) NOT NULL,
+    channel VARCHAR(10) NOT NULL,
+    locale VARCHAR(5) NOT NULL,
+    recipient VARCHAR(320) NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_loan_notifications_application ON loan_notifications (application_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_loan_notifications_due ON loan_notifications (next_attempt_at) WHERE status = 'pending';
+
+-- One row per delivery attempt, successful or not.
+CREATE TABLE IF NOT EXISTS loan_notification_attempts (
+    attempt_id UUID PRIMARY KEY,
+    notification_id UUID NOT NULL REFERENCES loan_notifications (notification_id) ON DELETE CASCADE,
+    application_id UUID NOT NULL REFERENCES loan_applications (application_id) ON DELETE CASCADE,
+    channel VARCHAR(10) NOT NULL,
+    provider VARCHAR(30) NOT NULL,
+    attempted_at TIMESTAMPTZ NOT NULL,
+    succeeded BOOLEAN NOT NULL,
+    error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_loan_notification_attempts_application ON loan_notification_attempts (application_id, attempted_at);
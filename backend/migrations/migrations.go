@@ -0,0 +1,9 @@
+package migrations
+
+import "embed"
+
+// FS holds the schema migrations applied by database.Migrate, in file name
+// order.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,142 @@
+package amortization
+
+import (
+	"backend-loan-pre-approval/pkg/money"
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrInvalidTerm      = errors.New("term must be at least 1 month")
+	ErrInvalidRate      = errors.New("interest rate must not be negative")
+	ErrInvalidPrincipal = errors.New("principal must be positive")
+)
+
+// Payment is one month of a level-payment schedule.
+type Payment struct {
+	Month     int         `json:"month"`
+	Payment   money.Money `json:"payment"`
+	Principal money.Money `json:"principal"`
+	Interest  money.Money `json:"interest"`
+	Balance   money.Money `json:"balance"`
+}
+
+// Schedule is a fully amortizing loan repaid in equal monthly installments.
+type Schedule struct {
+	Principal          money.Money `json:"principal"`
+	AnnualRateBps      int         `json:"annualRateBps"`
+	TermMonths         int         `json:"termMonths"`
+	MonthlyInstallment money.Money `json:"monthlyInstallment"`
+	TotalInterest      money.Money `json:"totalInterest"`
+	TotalPayment       money.Money `json:"totalPayment"`
+	Payments           []Payment   `json:"schedule,omitempty"`
+}
+
+// Calculate amortizes principal over termMonths at annualRateBps basis
+// points per year, compounded monthly. The installment and each month's
+// interest are rounded half away from zero to the currency's minor unit; the
+// final payment absorbs the rounding so the balance ends at exactly zero.
+func Calculate(principal money.Money, annualRateBps int, termMonths int) (Schedule, error) {
+	if termMonths < 1 {
+		return Schedule{}, ErrInvalidTerm
+	}
+	if annualRateBps < 0 {
+		return Schedule{}, ErrInvalidRate
+	}
+	if principal.Amount <= 0 {
+		return Schedule{}, ErrInvalidPrincipal
+	}
+
+	monthlyRate := big.NewRat(int64(annualRateBps), 10000*12)
+
+	installment, err := Installment(principal, annualRateBps, termMonths)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule := Schedule{
+		Principal:          principal,
+		AnnualRateBps:      annualRateBps,
+		TermMonths:         termMonths,
+		MonthlyInstallment: installment,
+		TotalInterest:      money.New(0, principal.Currency),
+		TotalPayment:       money.New(0, principal.Currency),
+	}
+
+	balance := principal
+	for month := 1; month <= termMonths; month++ {
+		interest, err := money.FromRat(new(big.Rat).Mul(balance.Rat(), monthlyRate), principal.Currency)
+		if err != nil {
+			return Schedule{}, err
+		}
+
+		payment := installment
+		principalPaid := money.New(payment.Amount-interest.Amount, principal.Currency)
+		if month == termMonths || principalPaid.Amount > balance.Amount {
+			principalPaid = balance
+			payment = money.New(balance.Amount+interest.Amount, principal.Currency)
+		}
+		balance = money.New(balance.Amount-principalPaid.Amount, principal.Currency)
+
+		schedule.TotalInterest.Amount += interest.Amount
+		schedule.TotalPayment.Amount += payment.Amount
+		schedule.Payments = append(schedule.Payments, Payment{
+			Month:     month,
+			Payment:   payment,
+			Principal: principalPaid,
+			Interest:  interest,
+			Balance:   balance,
+		})
+
+		if balance.IsZero() {
+			break
+		}
+	}
+
+	return schedule, nil
+}
+
+// Installment returns the level monthly payment P*r / (1 - (1+r)^-n), or
+// P/n at a zero rate, rounded half away from zero.
+func Installment(principal money.Money, annualRateBps int, termMonths int) (money.Money, error) {
+	if termMonths < 1 {
+		return money.Money{}, ErrInvalidTerm
+	}
+	if annualRateBps < 0 {
+		return money.Money{}, ErrInvalidRate
+	}
+
+	p := principal.Rat()
+	if annualRateBps == 0 {
+		return money.FromRat(p.Quo(p, big.NewRat(int64(termMonths), 1)), principal.Currency)
+	}
+
+	r := big.NewRat(int64(annualRateBps), 10000*12)
+	growth := pow(new(big.Rat).Add(big.NewRat(1, 1), r), termMonths)
+
+	// P*r*(1+r)^n / ((1+r)^n - 1)
+	num := new(big.Rat).Mul(p, r)
+	num.Mul(num, growth)
+	den := new(big.Rat).Sub(growth, big.NewRat(1, 1))
+
+	return money.FromRat(num.Quo(num, den), principal.Currency)
+}
+
+func pow(base *big.Rat, n int) *big.Rat {
+	result := big.NewRat(1, 1)
+	b := new(big.Rat).Set(base)
+	for n > 0 {
+		if n&1 == 1 {
+			result.Mul(result, b)
+		}
+		b.Mul(b, b)
+		n >>= 1
+	}
+	return result
+}
+
+// Summary returns s without the month-by-month payments.
+func (s Schedule) Summary() Schedule {
+	s.Payments = nil
+	return s
+}
@@ -0,0 +1,40 @@
+package amortization
+
+import (
+	"backend-loan-pre-approval/pkg/money"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestCalculate(t *testing.T) {
+	// 100,000 THB over 12 months at 6% a year
+	schedule, err := Calculate(money.FromMajor(100000, "THB"), 600, 12)
+	assert.NilError(t, err)
+
+	last := schedule.Payments[len(schedule.Payments)-1]
+
+	// Assert
+	assert.Equal(t, money.New(860664, "THB"), schedule.MonthlyInstallment)
+	assert.Equal(t, 12, len(schedule.Payments))
+	assert.Equal(t, int64(0), last.Balance.Amount)
+	assert.Equal(t, schedule.TotalPayment.Amount, schedule.Principal.Amount+schedule.TotalInterest.Amount)
+	assert.Equal(t, money.New(50000, "THB"), schedule.Payments[0].Interest)
+}
+
+func TestCalculate_ZeroRate(t *testing.T) {
+	schedule, err := Calculate(money.FromMajor(1000, "THB"), 0, 3)
+	assert.NilError(t, err)
+
+	// Assert
+	assert.Equal(t, money.New(33333, "THB"), schedule.MonthlyInstallment)
+	assert.Equal(t, money.New(33334, "THB"), schedule.Payments[2].Payment)
+	assert.Equal(t, int64(0), schedule.TotalInterest.Amount)
+}
+
+func TestCalculate_InvalidTerm(t *testing.T) {
+	_, err := Calculate(money.FromMajor(1000, "THB"), 500, 0)
+
+	// Assert
+	assert.Equal(t, ErrInvalidTerm, err)
+}
@@ -0,0 +1,75 @@
+// Package birthdate parses applicant dates of birth and derives ages from
+// them on the calendar of a configured time zone.
+package birthdate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	// The runtime image has no zoneinfo database.
+	_ "time/tzdata"
+)
+
+// Layout is the format dates of birth are sent and returned in.
+const Layout = "2006-01-02"
+
+var ErrInvalidDate = errors.New("date of birth is not a valid YYYY-MM-DD date")
+
+var location atomic.Pointer[time.Location]
+
+func init() {
+	loc, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		panic(err)
+	}
+	location.Store(loc)
+}
+
+// SetLocation sets the IANA time zone, e.g. "Asia/Bangkok", whose calendar
+// decides on which day a birthday falls.
+func SetLocation(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("unsupported time zone %q: %w", name, err)
+	}
+	location.Store(loc)
+	return nil
+}
+
+func Location() *time.Location {
+	return location.Load()
+}
+
+// Parse reads a YYYY-MM-DD date. The result is midnight UTC; only its
+// calendar date is meaningful.
+func Parse(input string) (time.Time, error) {
+	dob, err := time.Parse(Layout, strings.TrimSpace(input))
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	return dob, nil
+}
+
+// AgeAt returns the age in whole years on the day t falls on in the
+// configured location. Someone born on 29 February turns a year older on
+// 1 March in common years.
+func AgeAt(dob time.Time, t time.Time) int {
+	year, month, day := t.In(Location()).Date()
+	birthYear, birthMonth, birthDay := dob.Date()
+
+	age := year - birthYear
+	if month < birthMonth || (month == birthMonth && day < birthDay) {
+		age--
+	}
+	return age
+}
+
+// Today is the current date in the configured location, as Parse would
+// return it.
+func Today(now time.Time) time.Time {
+	year, month, day := now.In(Location()).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
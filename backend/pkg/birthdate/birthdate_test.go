@@ -0,0 +1,65 @@
+package birthdate
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected error
+	}{
+		{"1990-05-17", nil},
+		{" 2000-02-29 ", nil},
+		{"2001-02-29", ErrInvalidDate},
+		{"17/05/1990", ErrInvalidDate},
+		{"", ErrInvalidDate},
+	}
+
+	for _, c := range cases {
+		_, err := Parse(c.input)
+
+		// Assert
+		assert.Equal(t, c.expected, err, c.input)
+	}
+}
+
+func TestAgeAt(t *testing.T) {
+	dob, _ := Parse("1990-05-17")
+	leap, _ := Parse("2000-02-29")
+	bangkok := time.FixedZone("ICT", 7*3600)
+
+	cases := []struct {
+		dob      time.Time
+		at       time.Time
+		expected int
+	}{
+		{dob, time.Date(2025, 5, 16, 12, 0, 0, 0, bangkok), 34},
+		{dob, time.Date(2025, 5, 17, 0, 0, 0, 0, bangkok), 35},
+		// 18:00 UTC on the 16th is already the 17th in Bangkok.
+		{dob, time.Date(2025, 5, 16, 18, 0, 0, 0, time.UTC), 35},
+		{leap, time.Date(2025, 2, 28, 12, 0, 0, 0, bangkok), 24},
+		{leap, time.Date(2025, 3, 1, 12, 0, 0, 0, bangkok), 25},
+		{leap, time.Date(2024, 2, 29, 12, 0, 0, 0, bangkok), 24},
+	}
+
+	for _, c := range cases {
+		// Assert
+		assert.Equal(t, c.expected, AgeAt(c.dob, c.at), c.at.String())
+	}
+}
+
+func TestSetLocation(t *testing.T) {
+	defer SetLocation("Asia/Bangkok")
+	dob, _ := Parse("1990-05-17")
+	at := time.Date(2025, 5, 16, 18, 0, 0, 0, time.UTC)
+
+	assert.NilError(t, SetLocation("UTC"))
+
+	// Assert
+	assert.Equal(t, 34, AgeAt(dob, at))
+	assert.ErrorContains(t, SetLocation("Mars/Olympus"), "unsupported time zone")
+}
@@ -0,0 +1,40 @@
+// Package blobstore keeps files such as uploaded documents outside the
+// database, on the local filesystem or in an S3-compatible bucket.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore stores blobs under slash-separated keys such as
+// "documents/ab/abcdef...". Put replaces any blob already stored under key.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get returns ErrNotFound when nothing is stored under key. The caller
+	// closes the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// checkKey rejects keys that could leave the store's root, such as
+// "../etc/passwd" or "/tmp/x".
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package creditbureau
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type Options struct {
+	// Timeout bounds each attempt, not the call as a whole.
+	Timeout      time.Duration
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// FailureThreshold consecutive failed calls open the circuit for
+	// OpenDuration, after which one trial call is let through.
+	FailureThreshold int
+	OpenDuration     time.Duration
+}
+
+// Client wraps a provider with a per-attempt timeout, retries with linear
+// backoff and a circuit breaker, so a slow or failing bureau cannot hold up
+// loan creation. ErrRejected is returned at once: the bureau answered, so it
+// is not retried and does not count as a failure.
+type Client struct {
+	bureau  CreditBureau
+	opts    Options
+	breaker *breaker
+}
+
+func NewClient(bureau CreditBureau, opts Options) *Client {
+	return &Client{
+		bureau: bureau,
+		opts:   opts,
+		breaker: &breaker{
+			threshold: opts.FailureThreshold,
+			openFor:   opts.OpenDuration,
+			now:       time.Now,
+		},
+	}
+}
+
+func (c *Client) Name() string {
+	return c.bureau.Name()
+}
+
+func (c *Client) Fetch(ctx context.Context, inquiry Inquiry) (Report, error) {
+	if !c.breaker.allow() {
+		return Report{}, ErrCircuitOpen
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				c.breaker.failure()
+				return Report{}, ctx.Err()
+			case <-time.After(c.opts.RetryBackoff * time.Duration(attempt)):
+			}
+		}
+
+		report, err := c.attempt(ctx, inquiry)
+		if err == nil {
+			c.breaker.success()
+			return report, nil
+		}
+		if errors.Is(err, ErrRejected) {
+			c.breaker.success()
+			return Report{}, err
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	c.breaker.failure()
+	return Report{}, lastErr
+}
+
+func (c *Client) attempt(ctx context.Context, inquiry Inquiry) (Report, error) {
+	if c.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+		defer cancel()
+	}
+	return c.bureau.Fetch(ctx, inquiry)
+}
+
+// breaker counts consecutive failed calls. A threshold of 0 disables it.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	openFor   time.Duration
+	failures  int
+	openedAt  time.Time
+	now       func() time.Time
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return true
+	}
+	if b.now().Sub(b.openedAt) >= b.openFor {
+		// Half-open: let one call through and hold the rest until it ends.
+		b.openedAt = b.now()
+		return true
+	}
+	return false
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+}
+
+func (b *breaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
@@ -0,0 +1,131 @@
+package creditbureau
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+// flakyBureau fails the first failures calls, then answers with a hit.
+type flakyBureau struct {
+	failures int
+	calls    int
+}
+
+func (b *flakyBureau) Name() string {
+	return "flaky"
+}
+
+func (b *flakyBureau) Fetch(ctx context.Context, inquiry Inquiry) (Report, error) {
+	b.calls++
+	if b.calls <= b.failures {
+		return Report{}, ErrProviderFailure
+	}
+	return Report{Provider: b.Name(), Status: StatusHit, Score: 700}, nil
+}
+
+func TestClient_RetriesUntilSuccess(t *testing.T) {
+	bureau := &flakyBureau{failures: 2}
+	client := NewClient(bureau, Options{MaxRetries: 2, RetryBackoff: time.Millisecond})
+
+	report, err := client.Fetch(context.Background(), Inquiry{})
+
+	// Assert
+	assert.NilError(t, err)
+	assert.Equal(t, 700, report.Score)
+	assert.Equal(t, 3, bureau.calls)
+}
+
+func TestClient_CircuitOpensAfterThreshold(t *testing.T) {
+	bureau := &flakyBureau{failures: 10}
+	client := NewClient(bureau, Options{FailureThreshold: 2, OpenDuration: time.Minute})
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Fetch(context.Background(), Inquiry{})
+		assert.Assert(t, errors.Is(err, ErrProviderFailure))
+	}
+	_, err := client.Fetch(context.Background(), Inquiry{})
+
+	// Assert: open circuits fail fast without calling the provider
+	assert.Assert(t, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(t, 2, bureau.calls)
+
+	now = now.Add(time.Minute)
+	bureau.failures = 0
+	report, err := client.Fetch(context.Background(), Inquiry{})
+
+	// Assert: the half-open trial succeeds and closes the circuit
+	assert.NilError(t, err)
+	assert.Equal(t, StatusHit, report.Status)
+}
+
+func TestClient_TimeoutFromStub(t *testing.T) {
+	bureau := NewFileBureau(StubData{
+		Subjects: map[string]StubEntry{"slow@example.com": {Status: "timeout"}},
+	})
+	client := NewClient(bureau, Options{Timeout: 10 * time.Millisecond, MaxRetries: 1})
+
+	_, err := client.Fetch(context.Background(), Inquiry{Email: "slow@example.com"})
+
+	// Assert
+	assert.Assert(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestHTTPBureau_AgainstStubServer(t *testing.T) {
+	server := httptest.NewServer(NewStubHandler(NewFileBureau(StubData{
+		Subjects: map[string]StubEntry{"demo@example.com": {Status: StatusHit, Score: 720}},
+	})))
+	defer server.Close()
+	bureau := NewHTTPBureau(server.URL, server.Client())
+
+	hit, err := bureau.Fetch(context.Background(), Inquiry{Email: "Demo@example.com"})
+	assert.NilError(t, err)
+	noHit, err := bureau.Fetch(context.Background(), Inquiry{Email: "nobody@example.com"})
+	assert.NilError(t, err)
+
+	// Assert
+	assert.Equal(t, StatusHit, hit.Status)
+	assert.Equal(t, 720, hit.Score)
+	assert.Equal(t, StatusNoHit, noHit.Status)
+}
+
+func TestClient_RejectionIsNotRetried(t *testing.T) {
+	calls := 0
+	status, body := http.StatusBadRequest, ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	bureau := NewHTTPBureau(server.URL, server.Client())
+	client := NewClient(bureau, Options{MaxRetries: 2, RetryBackoff: time.Millisecond, FailureThreshold: 1, OpenDuration: time.Minute})
+
+	_, rejected := client.Fetch(context.Background(), Inquiry{})
+	rejectedCalls := calls
+	// A rejection leaves the circuit closed, so the bureau is asked again.
+	_, again := client.Fetch(context.Background(), Inquiry{})
+
+	calls, status = 0, http.StatusServiceUnavailable
+	_, busy := client.Fetch(context.Background(), Inquiry{})
+	busyCalls := calls
+
+	status, body = http.StatusOK, strings.Repeat(" ", maxResponseBytes+1)
+	_, large := bureau.Fetch(context.Background(), Inquiry{})
+
+	// Assert
+	assert.Assert(t, errors.Is(rejected, ErrRejected))
+	assert.Equal(t, 1, rejectedCalls)
+	assert.Assert(t, errors.Is(again, ErrRejected))
+	assert.Assert(t, errors.Is(busy, ErrProviderFailure))
+	assert.Equal(t, 3, busyCalls)
+	assert.ErrorContains(t, large, "response exceeds")
+}
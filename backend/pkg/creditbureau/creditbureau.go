@@ -0,0 +1,61 @@
+package creditbureau
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Report statuses. StatusUnavailable is never returned by a provider; the
+// caller records it when the bureau could not be reached.
+const (
+	StatusHit         = "hit"
+	StatusNoHit       = "noHit"
+	StatusUnavailable = "unavailable"
+)
+
+var (
+	ErrCircuitOpen     = errors.New("credit bureau circuit open")
+	ErrProviderFailure = errors.New("credit bureau provider failure")
+	// ErrRejected is a provider refusing the inquiry itself, e.g. a
+	// malformed or unknown ID. Sending it again cannot succeed, so it is
+	// neither retried nor counted against the provider's health.
+	ErrRejected = errors.New("credit bureau rejected inquiry")
+)
+
+// Inquiry identifies the applicant to the bureau. NationalId is the
+// preferred key and is empty when the applicant did not give one;
+// PhoneNumber is in E.164.
+type Inquiry struct {
+	ApplicationId string `json:"applicationId"`
+	NationalId    string `json:"nationalId,omitempty"`
+	FullName      string `json:"fullName"`
+	PhoneNumber   string `json:"phoneNumber"`
+	Email         string `json:"email"`
+}
+
+// Report is the bureau's answer for one inquiry. Score is only set for a hit.
+// Raw is the provider's response as received and is stored separately from
+// the decision.
+type Report struct {
+	Provider    string          `json:"provider"`
+	Status      string          `json:"status"`
+	Score       int             `json:"score,omitempty"`
+	RetrievedAt time.Time       `json:"retrievedAt"`
+	Raw         json.RawMessage `json:"-"`
+}
+
+// Unavailable is the report recorded when provider could not be reached.
+func Unavailable(provider string, at time.Time) Report {
+	return Report{
+		Provider:    provider,
+		Status:      StatusUnavailable,
+		RetrievedAt: at,
+	}
+}
+
+type CreditBureau interface {
+	Name() string
+	Fetch(ctx context.Context, inquiry Inquiry) (Report, error)
+}
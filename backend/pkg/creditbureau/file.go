@@ -0,0 +1,114 @@
+package creditbureau
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Stub behaviours besides a normal hit or no-hit.
+const (
+	stubTimeout = "timeout"
+	stubError   = "error"
+)
+
+// StubEntry is the canned answer for one subject. Status is hit, noHit,
+// timeout (block until the caller gives up) or error. Delay is applied
+// before answering.
+type StubEntry struct {
+	Status  string `json:"status"`
+	Score   int    `json:"score,omitempty"`
+	DelayMs int    `json:"delayMs,omitempty"`
+}
+
+// StubData is the content of a stub file. Subjects are keyed by national ID,
+// email or phone number, tried in that order; anyone else gets Default.
+//
+//	{
+//		"default": {"status": "noHit"},
+//		"subjects": {
+//			"demo@example.com": {"status": "hit", "score": 720},
+//			"+66800000000": {"status": "timeout"}
+//		}
+//	}
+type StubData struct {
+	Default  StubEntry            `json:"default"`
+	Subjects map[string]StubEntry `json:"subjects"`
+}
+
+// FileBureau answers inquiries from a stub file, for local runs and tests.
+type FileBureau struct {
+	data StubData
+}
+
+func NewFileBureau(data StubData) *FileBureau {
+	if data.Default.Status == "" {
+		data.Default.Status = StatusNoHit
+	}
+	return &FileBureau{data: data}
+}
+
+func LoadFile(path string) (*FileBureau, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var data StubData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, fmt.Errorf("credit bureau stub %s: %w", path, err)
+	}
+	return NewFileBureau(data), nil
+}
+
+func (b *FileBureau) Name() string {
+	return "file"
+}
+
+func (b *FileBureau) Fetch(ctx context.Context, inquiry Inquiry) (Report, error) {
+	entry := b.lookup(inquiry)
+
+	if entry.DelayMs > 0 {
+		select {
+		case <-ctx.Done():
+			return Report{}, ctx.Err()
+		case <-time.After(time.Duration(entry.DelayMs) * time.Millisecond):
+		}
+	}
+
+	switch entry.Status {
+	case stubTimeout:
+		<-ctx.Done()
+		return Report{}, ctx.Err()
+	case stubError:
+		return Report{}, ErrProviderFailure
+	}
+
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return Report{}, err
+	}
+	return Report{
+		Provider:    b.Name(),
+		Status:      entry.Status,
+		Score:       entry.Score,
+		RetrievedAt: time.Now(),
+		Raw:         raw,
+	}, nil
+}
+
+func (b *FileBureau) lookup(inquiry Inquiry) StubEntry {
+	if entry, ok := b.data.Subjects[inquiry.NationalId]; ok && inquiry.NationalId != "" {
+		return entry
+	}
+	if entry, ok := b.data.Subjects[strings.ToLower(inquiry.Email)]; ok {
+		return entry
+	}
+	if entry, ok := b.data.Subjects[inquiry.PhoneNumber]; ok {
+		return entry
+	}
+	return b.data.Default
+}
@@ -0,0 +1,130 @@
+package creditbureau
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const reportsPath = "/v1/reports"
+
+// maxResponseBytes caps how much of a bureau response is read and stored.
+const maxResponseBytes = 1 << 20
+
+// HTTPBureau posts the inquiry as JSON to {baseURL}/v1/reports. A 200 carries
+// {"status": ..., "score": ...}; a 404 is a no-hit and any other 4xx is
+// ErrRejected.
+type HTTPBureau struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewHTTPBureau(baseURL string, client *http.Client) *HTTPBureau {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPBureau{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+	}
+}
+
+func (b *HTTPBureau) Name() string {
+	return "http"
+}
+
+func (b *HTTPBureau) Fetch(ctx context.Context, inquiry Inquiry) (Report, error) {
+	body, err := json.Marshal(inquiry)
+	if err != nil {
+		return Report{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+reportsPath, bytes.NewReader(body))
+	if err != nil {
+		return Report{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return Report{}, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		return Report{}, err
+	}
+	if len(raw) > maxResponseBytes {
+		return Report{}, fmt.Errorf("%w: response exceeds %d bytes", ErrProviderFailure, maxResponseBytes)
+	}
+
+	report := Report{
+		Provider:    b.Name(),
+		RetrievedAt: time.Now(),
+		Raw:         raw,
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var payload struct {
+			Status string `json:"status"`
+			Score  int    `json:"score"`
+		}
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return Report{}, err
+		}
+		report.Status = payload.Status
+		report.Score = payload.Score
+		return report, nil
+	case http.StatusNotFound:
+		report.Status = StatusNoHit
+		return report, nil
+	}
+	// Timeouts and rate limiting are the bureau's trouble, not the inquiry's.
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return Report{}, fmt.Errorf("%w: status %d", ErrRejected, resp.StatusCode)
+	}
+	return Report{}, fmt.Errorf("%w: status %d", ErrProviderFailure, resp.StatusCode)
+}
+
+// NewStubHandler serves the HTTPBureau protocol from another provider,
+// usually a FileBureau, so the HTTP path can be exercised locally.
+func NewStubHandler(bureau CreditBureau) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(reportsPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var inquiry Inquiry
+		if err := json.NewDecoder(r.Body).Decode(&inquiry); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		report, err := bureau.Fetch(r.Context(), inquiry)
+		if err != nil {
+			log.Println("err: ", err)
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if report.Status == StatusNoHit {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": report.Status,
+			"score":  report.Score,
+		})
+	})
+	return mux
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"io/fs"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// migrationLockKey serializes Migrate across replicas starting together.
+const migrationLockKey = 72410001
+
+// Migrate applies every *.sql file in fsys that is not yet recorded in
+// schema_migrations, in file name order, each in its own transaction.
+func Migrate(ctx context.Context, db *sqlx.DB, fsys fs.FS) error {
+
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version VARCHAR(255) PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`); err != nil {
+		return err
+	}
+
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	txManager := NewTxManager(db)
+	for _, file := range files {
+		version := strings.TrimSuffix(file, ".sql")
+
+		err := txManager.WithTx(ctx, func(ctx context.Context) error {
+			conn := Conn(ctx, db)
+			if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLockKey); err != nil {
+				return err
+			}
+
+			var applied bool
+			if err := conn.QueryRowxContext(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version).Scan(&applied); err != nil {
+				return err
+			}
+			if applied {
+				return nil
+			}
+
+			content, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return err
+			}
+			if _, err := conn.ExecContext(ctx, string(content)); err != nil {
+				return err
+			}
+			if _, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+				return err
+			}
+
+			log.Println("migration applied: ", version)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
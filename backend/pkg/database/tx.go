@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DBTX is the query surface shared by *sqlx.DB and *sqlx.Tx, so repositories
+// can run the same statements inside or outside a transaction.
+type DBTX interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// Transactor runs fn as a single unit of work. Repositories called with the
+// ctx passed to fn pick up the transaction through Conn.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type TxManager struct {
+	db *sqlx.DB
+}
+
+func NewTxManager(db *sqlx.DB) *TxManager {
+	return &TxManager{
+		db: db,
+	}
+}
+
+type txKey struct{}
+
+type txState struct {
+	tx        *sqlx.Tx
+	savepoint int
+}
+
+// WithTx begins a transaction, commits it when fn returns nil and rolls it
+// back otherwise. A nested call on a ctx that already carries a transaction
+// runs fn inside a savepoint instead, so only the nested work is undone on
+// error.
+func (m *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.withSavepoint(ctx, fn)
+	}
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, &txState{tx: tx})); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Println("rollback err: ", rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *txState) withSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	s.savepoint++
+	name := fmt.Sprintf("sp_%d", s.savepoint)
+
+	if _, err := s.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = s.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		if _, rbErr := s.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			log.Println("rollback savepoint err: ", rbErr)
+		}
+		return err
+	}
+
+	_, err := s.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none.
+func Conn(ctx context.Context, db *sqlx.DB) DBTX {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx
+	}
+	return db
+}
@@ -0,0 +1,27 @@
+package database
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTransactor struct {
+	mock.Mock
+}
+
+// Helper function to create a new transactor with mocks
+func NewMockTransactor() *MockTransactor {
+
+	return &MockTransactor{}
+}
+
+// WithTx records the call and, unless the expectation returns an error, runs
+// fn directly on ctx without a real transaction.
+func (m *MockTransactor) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"gotest.tools/assert"
+)
+
+// recorder is a database/sql driver that runs nothing and records the
+// transaction statements it is given, in order.
+type recorder struct {
+	mu  sync.Mutex
+	log []string
+}
+
+func (r *recorder) record(statement string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.log = append(r.log, statement)
+}
+
+type recorderConn struct {
+	r *recorder
+}
+
+func (c *recorderConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+
+func (c *recorderConn) Close() error {
+	return nil
+}
+
+func (c *recorderConn) Begin() (driver.Tx, error) {
+	c.r.record("BEGIN")
+	return c, nil
+}
+
+func (c *recorderConn) Commit() error {
+	c.r.record("COMMIT")
+	return nil
+}
+
+func (c *recorderConn) Rollback() error {
+	c.r.record("ROLLBACK")
+	return nil
+}
+
+func (c *recorderConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.r.record(query)
+	return driver.RowsAffected(0), nil
+}
+
+var registerRecorder sync.Once
+
+func newTestTxManager(t *testing.T) (*TxManager, *recorder) {
+	rec := &recorder{}
+	registerRecorder.Do(func() {
+		sql.Register("recorder", &recorderDriver{})
+	})
+	recorders.Store(t.Name(), rec)
+	t.Cleanup(func() { recorders.Delete(t.Name()) })
+
+	db, err := sqlx.Open("recorder", t.Name())
+	assert.NilError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewTxManager(db), rec
+}
+
+// recorderDriver hands each test its own recorder, keyed by the DSN.
+type recorderDriver struct{}
+
+var recorders sync.Map
+
+func (recorderDriver) Open(name string) (driver.Conn, error) {
+	rec, ok := recorders.Load(name)
+	if !ok {
+		return nil, errors.New("no recorder for " + name)
+	}
+	return &recorderConn{r: rec.(*recorder)}, nil
+}
+
+func Test_WithTx_Commit(t *testing.T) {
+	m, rec := newTestTxManager(t)
+
+	err := m.WithTx(t.Context(), func(ctx context.Context) error {
+		_, err := Conn(ctx, m.db).ExecContext(ctx, "INSERT 1")
+		return err
+	})
+	_, outside := Conn(t.Context(), m.db).(*sqlx.DB)
+
+	// Assert
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"BEGIN", "INSERT 1", "COMMIT"}, rec.log)
+	assert.Assert(t, outside)
+}
+
+func Test_WithTx_Rollback(t *testing.T) {
+	m, rec := newTestTxManager(t)
+	failed := errors.New("failed")
+
+	err := m.WithTx(t.Context(), func(ctx context.Context) error {
+		return failed
+	})
+
+	// Assert
+	assert.Equal(t, failed, err)
+	assert.DeepEqual(t, []string{"BEGIN", "ROLLBACK"}, rec.log)
+}
+
+func Test_WithTx_NestedFailureRollsBackToSavepoint(t *testing.T) {
+	m, rec := newTestTxManager(t)
+	failed := errors.New("failed")
+
+	var innerErr error
+	err := m.WithTx(t.Context(), func(ctx context.Context) error {
+		if _, err := Conn(ctx, m.db).ExecContext(ctx, "INSERT 1"); err != nil {
+			return err
+		}
+		innerErr = m.WithTx(ctx, func(ctx context.Context) error {
+			_, _ = Conn(ctx, m.db).ExecContext(ctx, "INSERT 2")
+			return failed
+		})
+		return m.WithTx(ctx, func(ctx context.Context) error {
+			_, err := Conn(ctx, m.db).ExecContext(ctx, "INSERT 3")
+			return err
+		})
+	})
+
+	// Assert
+	assert.NilError(t, err)
+	assert.Equal(t, failed, innerErr)
+	assert.DeepEqual(t, []string{
+		"BEGIN",
+		"INSERT 1",
+		"SAVEPOINT sp_1",
+		"INSERT 2",
+		"ROLLBACK TO SAVEPOINT sp_1",
+		"SAVEPOINT sp_2",
+		"INSERT 3",
+		"RELEASE SAVEPOINT sp_2",
+		"COMMIT",
+	}, rec.log)
+}
+
+func Test_WithTx_PanicRollsBack(t *testing.T) {
+	m, rec := newTestTxManager(t)
+
+	recovered := func() (p any) {
+		defer func() { p = recover() }()
+		_ = m.WithTx(t.Context(), func(ctx context.Context) error {
+			return m.WithTx(ctx, func(ctx context.Context) error {
+				panic("boom")
+			})
+		})
+		return nil
+	}()
+
+	// Assert
+	assert.Equal(t, "boom", recovered)
+	assert.DeepEqual(t, []string{
+		"BEGIN",
+		"SAVEPOINT sp_1",
+		"ROLLBACK TO SAVEPOINT sp_1",
+		"ROLLBACK",
+	}, rec.log)
+}
@@ -0,0 +1,70 @@
+package emailaddr
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Blocklist is a set of disposable email domains. A listed domain also
+// blocks its subdomains.
+type Blocklist struct {
+	domains map[string]struct{}
+}
+
+func NewBlocklist(domains []string) (*Blocklist, error) {
+	b := &Blocklist{domains: map[string]struct{}{}}
+	for _, v := range domains {
+		domain, err := normalizeDomain(v)
+		if err != nil {
+			return nil, fmt.Errorf("blocklisted domain %q: %w", v, err)
+		}
+		b.domains[domain] = struct{}{}
+	}
+	return b, nil
+}
+
+// LoadBlocklist reads one domain per line. Blank lines and lines starting
+// with "#" are skipped.
+func LoadBlocklist(path string) (*Blocklist, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	domains := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	b, err := NewBlocklist(domains)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
+
+// Contains reports whether domain, in normalized form, or any of its parent
+// domains is listed.
+func (b *Blocklist) Contains(domain string) bool {
+	for {
+		if _, ok := b.domains[domain]; ok {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
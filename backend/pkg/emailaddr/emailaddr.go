@@ -0,0 +1,94 @@
+// Package emailaddr parses applicant email addresses per RFC 5322 and
+// normalizes them for storage.
+package emailaddr
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/net/idna"
+)
+
+var (
+	ErrInvalidAddress   = errors.New("email address is not valid")
+	ErrDisposableDomain = errors.New("email domain is a disposable email provider")
+)
+
+// Address is a parsed email address. Domain is the lowercase ASCII (IDNA)
+// form; Normalized joins it with the local part, whose case is kept since
+// RFC 5321 leaves it to the receiving server.
+type Address struct {
+	Local      string
+	Domain     string
+	Normalized string
+}
+
+var blocklist atomic.Pointer[Blocklist]
+
+// SetBlocklist replaces the disposable domains Parse rejects; nil disables
+// the check.
+func SetBlocklist(b *Blocklist) {
+	blocklist.Store(b)
+}
+
+// Parse accepts a bare addr-spec such as "demo@example.com" or
+// "\"john doe\"@bücher.de", but not a display name form like
+// "Demo <demo@example.com>". The domain must have at least two labels.
+func Parse(input string) (Address, error) {
+	input = strings.TrimSpace(input)
+	parsed, err := mail.ParseAddress(input)
+	if err != nil || parsed.Name != "" || strings.ContainsAny(input, "<>") {
+		return Address{}, ErrInvalidAddress
+	}
+
+	at := strings.LastIndex(parsed.Address, "@")
+	local, domain := parsed.Address[:at], parsed.Address[at+1:]
+	if domain, err = normalizeDomain(domain); err != nil {
+		return Address{}, ErrInvalidAddress
+	}
+	if b := blocklist.Load(); b != nil && b.Contains(domain) {
+		return Address{}, ErrDisposableDomain
+	}
+
+	local = quoteLocal(local)
+	return Address{
+		Local:      local,
+		Domain:     domain,
+		Normalized: local + "@" + domain,
+	}, nil
+}
+
+func normalizeDomain(domain string) (string, error) {
+	ascii, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", err
+	}
+	labels := strings.Split(ascii, ".")
+	if len(labels) < 2 || len(labels[len(labels)-1]) < 2 {
+		return "", ErrInvalidAddress
+	}
+	return strings.ToLower(ascii), nil
+}
+
+// quoteLocal restores the quotes net/mail strips from a local part that
+// is not a dot-atom, e.g. one containing a space.
+func quoteLocal(local string) string {
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" || strings.IndexFunc(atom, func(r rune) bool { return !isAtext(r) }) >= 0 {
+			return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(local) + `"`
+		}
+	}
+	return local
+}
+
+// isAtext reports whether r may appear unquoted in a local part (RFC 5322
+// atext, extended to UTF-8 by RFC 6532).
+func isAtext(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r > 127:
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r)
+}
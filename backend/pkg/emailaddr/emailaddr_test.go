@@ -0,0 +1,58 @@
+package emailaddr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input      string
+		normalized string
+		expected   error
+	}{
+		{"demo@example.com", "demo@example.com", nil},
+		{" Demo@EXAMPLE.com ", "Demo@example.com", nil},
+		{`"john doe"@example.com`, `"john doe"@example.com`, nil},
+		{"user+loans@sub.example.co.th", "user+loans@sub.example.co.th", nil},
+		{"user@bücher.de", "user@xn--bcher-kva.de", nil},
+		{"user@ตัวอย่าง.ไทย", "user@xn--72c1a1bt4awk9o.xn--o3cw4h", nil},
+		{"demoexample.com", "", ErrInvalidAddress},
+		{"Demo <demo@example.com>", "", ErrInvalidAddress},
+		{"demo@localhost", "", ErrInvalidAddress},
+		{"demo@example.c", "", ErrInvalidAddress},
+		{"demo@-example.com", "", ErrInvalidAddress},
+		{"a..b@example.com", "", ErrInvalidAddress},
+	}
+
+	for _, c := range cases {
+		address, err := Parse(c.input)
+
+		// Assert
+		assert.Equal(t, c.expected, err, c.input)
+		assert.Equal(t, c.normalized, address.Normalized, c.input)
+	}
+}
+
+func TestParse_Blocklist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "disposable.txt")
+	err := os.WriteFile(path, []byte("# disposable providers\nmailinator.com\n\nYOPMAIL.com\n"), 0o600)
+	assert.NilError(t, err)
+
+	b, err := LoadBlocklist(path)
+	assert.NilError(t, err)
+	SetBlocklist(b)
+	defer SetBlocklist(nil)
+
+	_, listed := Parse("someone@mailinator.com")
+	_, subdomain := Parse("someone@eu.yopmail.com")
+	_, allowed := Parse("someone@example.com")
+
+	// Assert
+	assert.Equal(t, ErrDisposableDomain, listed)
+	assert.Equal(t, ErrDisposableDomain, subdomain)
+	assert.NilError(t, allowed)
+}
@@ -0,0 +1,164 @@
+package fx
+
+import (
+	"backend-loan-pre-approval/pkg/money"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Rate quotes how many units of the base currency one unit of Currency buys
+// from EffectiveDate until the next rate for the same currency.
+type Rate struct {
+	Currency      string
+	Value         string
+	EffectiveDate time.Time
+}
+
+// AppliedRate records the rate used for one conversion so a decision can be
+// explained later.
+type AppliedRate struct {
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Rate          string `json:"rate"`
+	EffectiveDate string `json:"effectiveDate"`
+}
+
+type Table struct {
+	base  string
+	rates map[string][]Rate
+}
+
+// NewTable indexes rates into base. Rates for base itself are ignored; it
+// always converts at 1.
+func NewTable(base string, rates []Rate) (*Table, error) {
+	t := &Table{
+		base:  base,
+		rates: map[string][]Rate{},
+	}
+
+	for _, r := range rates {
+		if _, ok := new(big.Rat).SetString(r.Value); !ok {
+			return nil, fmt.Errorf("invalid rate %q for %s", r.Value, r.Currency)
+		}
+		if !money.IsSupported(r.Currency) {
+			return nil, fmt.Errorf("unsupported currency %q", r.Currency)
+		}
+		if r.Currency == base {
+			continue
+		}
+		t.rates[r.Currency] = append(t.rates[r.Currency], r)
+	}
+
+	for _, list := range t.rates {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].EffectiveDate.Before(list[j].EffectiveDate)
+		})
+	}
+
+	return t, nil
+}
+
+// LoadFile reads a CSV file with the header currency,rate,effective_date,
+// e.g. "USD,35.25,2026-01-01".
+func LoadFile(path string, base string) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rates, err := readCSV(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return NewTable(base, rates)
+}
+
+func readCSV(r io.Reader) ([]Rate, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	rates := []Rate{}
+	for i, rec := range records {
+		if i == 0 || len(rec) == 0 {
+			continue
+		}
+		if len(rec) != 3 {
+			return nil, fmt.Errorf("line %d: expected currency,rate,effective_date", i+1)
+		}
+
+		date, err := time.Parse(dateLayout, strings.TrimSpace(rec[2]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+
+		rates = append(rates, Rate{
+			Currency:      strings.ToUpper(strings.TrimSpace(rec[0])),
+			Value:         strings.TrimSpace(rec[1]),
+			EffectiveDate: date,
+		})
+	}
+
+	return rates, nil
+}
+
+func (t *Table) Base() string {
+	return t.base
+}
+
+// Lookup returns the rate for currency in effect at the given time.
+func (t *Table) Lookup(currency string, at time.Time) (Rate, error) {
+	if currency == t.base {
+		return Rate{Currency: currency, Value: "1"}, nil
+	}
+
+	var found *Rate
+	for i, r := range t.rates[currency] {
+		if r.EffectiveDate.After(at) {
+			break
+		}
+		found = &t.rates[currency][i]
+	}
+	if found == nil {
+		return Rate{}, fmt.Errorf("no %s/%s exchange rate effective on %s", currency, t.base, at.Format(dateLayout))
+	}
+
+	return *found, nil
+}
+
+// ToBase converts m into the base currency with the rate in effect at the
+// given time, rounding half away from zero to the base minor unit.
+func (t *Table) ToBase(m money.Money, at time.Time) (money.Money, AppliedRate, error) {
+	rate, err := t.Lookup(m.Currency, at)
+	if err != nil {
+		return money.Money{}, AppliedRate{}, err
+	}
+
+	value, _ := new(big.Rat).SetString(rate.Value)
+	converted, err := money.FromRat(value.Mul(value, m.Rat()), t.base)
+	if err != nil {
+		return money.Money{}, AppliedRate{}, err
+	}
+
+	applied := AppliedRate{
+		From: m.Currency,
+		To:   t.base,
+		Rate: rate.Value,
+	}
+	if !rate.EffectiveDate.IsZero() {
+		applied.EffectiveDate = rate.EffectiveDate.Format(dateLayout)
+	}
+
+	return converted, applied, nil
+}
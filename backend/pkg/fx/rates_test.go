@@ -0,0 +1,40 @@
+package fx
+
+import (
+	"backend-loan-pre-approval/pkg/money"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestToBase_UsesRateEffectiveAtDecisionDate(t *testing.T) {
+	rates, err := readCSV(strings.NewReader("currency,rate,effective_date\nUSD,35.00,2026-01-01\nUSD,36.50,2026-06-01\nJPY,0.2345,2026-01-01\n"))
+	assert.NilError(t, err)
+	table, err := NewTable("THB", rates)
+	assert.NilError(t, err)
+
+	converted, applied, err := table.ToBase(money.FromMajor(1000, "USD"), time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC))
+	assert.NilError(t, err)
+
+	// Assert
+	assert.Equal(t, money.FromMajor(35000, "THB"), converted)
+	assert.Equal(t, AppliedRate{From: "USD", To: "THB", Rate: "35.00", EffectiveDate: "2026-01-01"}, applied)
+
+	converted, _, err = table.ToBase(money.New(12345, "JPY"), time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC))
+	assert.NilError(t, err)
+
+	// Assert: 12345 yen * 0.2345 = 2894.9025 baht, rounded to satang
+	assert.Equal(t, money.New(289490, "THB"), converted)
+}
+
+func TestToBase_NoRate(t *testing.T) {
+	table, err := NewTable("THB", nil)
+	assert.NilError(t, err)
+
+	_, _, err = table.ToBase(money.FromMajor(1, "USD"), time.Now())
+
+	// Assert
+	assert.ErrorContains(t, err, "no USD/THB exchange rate")
+}
@@ -0,0 +1,238 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// DefaultCurrency is assumed for amounts submitted without a currency.
+const DefaultCurrency = "THB"
+
+// minorUnits is the ISO 4217 exponent of each supported currency.
+var minorUnits = map[string]int{
+	"THB": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"SGD": 2,
+	"JPY": 0,
+}
+
+// decimalPattern is the amount syntax Parse accepts. big.Rat would also take
+// fractions such as "1/3" and hex such as "0x10"; neither is an amount.
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d{1,3})?$`)
+
+var (
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrInvalidAmount       = errors.New("invalid amount")
+	ErrAmountOutOfRange    = errors.New("amount out of range")
+)
+
+// Money is an exact amount held in the minor unit of its currency
+// (satang for THB, cents for USD, yen for JPY).
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func New(minor int64, currency string) Money {
+	return Money{Amount: minor, Currency: currency}
+}
+
+// FromMajor builds an amount from whole units, e.g. FromMajor(5000, "THB")
+// is 5,000.00 baht.
+func FromMajor(major int64, currency string) Money {
+	return Money{Amount: major * scale(currency), Currency: currency}
+}
+
+// Parse reads a decimal string such as "1234.5" or "1e4" in currency.
+// Digits beyond the currency's minor unit are rounded half away from zero,
+// so "10.005" THB is 10.01 and "-10.005" THB is -10.01.
+func Parse(s string, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	exp, ok := minorUnits[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+	}
+
+	s = strings.TrimSpace(s)
+	if !decimalPattern.MatchString(s) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	return fromRat(r, currency, exp)
+}
+
+// FromRat converts an exact amount in major units to currency, rounding
+// half away from zero like Parse.
+func FromRat(major *big.Rat, currency string) (Money, error) {
+	exp, ok := minorUnits[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+	}
+	return fromRat(major, currency, exp)
+}
+
+func fromRat(major *big.Rat, currency string, exp int) (Money, error) {
+	r := new(big.Rat).Mul(major, new(big.Rat).SetInt64(scale(currency)))
+	minor := roundHalfAwayFromZero(r)
+	if !minor.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s", ErrAmountOutOfRange, major.FloatString(exp))
+	}
+
+	return Money{Amount: minor.Int64(), Currency: currency}, nil
+}
+
+func roundHalfAwayFromZero(r *big.Rat) *big.Int {
+	num := new(big.Int).Abs(r.Num())
+	den := r.Denom()
+
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q
+}
+
+func scale(currency string) int64 {
+	s := int64(1)
+	for i := 0; i < minorUnits[currency]; i++ {
+		s *= 10
+	}
+	return s
+}
+
+// IsSupported reports whether currency is a known ISO 4217 code.
+func IsSupported(currency string) bool {
+	_, ok := minorUnits[currency]
+	return ok
+}
+
+// Exponent returns the number of minor-unit digits of currency.
+func Exponent(currency string) int {
+	return minorUnits[currency]
+}
+
+// Rat returns m in major units as an exact rational.
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac64(m.Amount, scale(m.Currency))
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Cmp compares the minor-unit amounts of m and o, which must share a
+// currency. It returns -1, 0 or +1.
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) LessThan(o Money) bool {
+	return m.Cmp(o) < 0
+}
+
+func (m Money) GreaterThan(o Money) bool {
+	return m.Cmp(o) > 0
+}
+
+// Add returns m plus o, which must share a currency.
+func (m Money) Add(o Money) Money {
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}
+}
+
+// Mul returns m multiplied by n.
+func (m Money) Mul(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// Decimal formats m in major units with the currency's minor-unit digits,
+// e.g. "50000.00" for THB or "7000" for JPY.
+func (m Money) Decimal() string {
+	exp := minorUnits[m.Currency]
+	if exp == 0 {
+		return fmt.Sprintf("%d", m.Amount)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	s := scale(m.Currency)
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/s, exp, amount%s)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+type jsonMoney struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// UnmarshalJSON accepts a JSON number (50000.5), a decimal string
+// ("50000.50") or an object {"amount": "50000.50", "currency": "USD"}.
+// The first two forms are in DefaultCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	currency := DefaultCurrency
+	if len(data) > 0 && data[0] == '{' {
+		var obj jsonMoney
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		if obj.Currency != "" {
+			currency = obj.Currency
+		}
+		data = bytes.TrimSpace(obj.Amount)
+	}
+
+	amount := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &amount); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := Parse(amount, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{
+		Amount:   m.Decimal(),
+		Currency: m.Currency,
+	})
+}
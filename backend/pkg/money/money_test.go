@@ -0,0 +1,72 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParse_Rounding(t *testing.T) {
+	cases := []struct {
+		input    string
+		currency string
+		expected int64
+	}{
+		{"50000", "THB", 5000000},
+		{"10.005", "THB", 1001},
+		{"10.004", "THB", 1000},
+		{"-10.005", "THB", -1001},
+		{"1e4", "USD", 1000000},
+		{"7000.5", "JPY", 7001},
+	}
+
+	for _, c := range cases {
+		m, err := Parse(c.input, c.currency)
+		assert.NilError(t, err)
+
+		// Assert
+		assert.Equal(t, c.expected, m.Amount, c.input)
+	}
+}
+
+func TestParse_UnsupportedCurrency(t *testing.T) {
+	_, err := Parse("100", "XXX")
+
+	// Assert
+	assert.ErrorContains(t, err, ErrUnsupportedCurrency.Error())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"1/3", "0x10", "1e", "abc", "", "1e1000000000"} {
+		_, err := Parse(input, "THB")
+
+		// Assert
+		assert.ErrorContains(t, err, ErrInvalidAmount.Error(), input)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var req struct {
+		Number Money `json:"number"`
+		String Money `json:"string"`
+		Object Money `json:"object"`
+	}
+
+	body := `{"number": 50000.5, "string": "1234.56", "object": {"amount": "99.99", "currency": "usd"}}`
+	err := json.Unmarshal([]byte(body), &req)
+	assert.NilError(t, err)
+
+	// Assert
+	assert.Equal(t, New(5000050, "THB"), req.Number)
+	assert.Equal(t, New(123456, "THB"), req.String)
+	assert.Equal(t, New(9999, "USD"), req.Object)
+}
+
+func TestMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(New(-5, "THB"))
+	assert.NilError(t, err)
+
+	// Assert
+	assert.Equal(t, `{"amount":"-0.05","currency":"THB"}`, string(b))
+}
@@ -0,0 +1,51 @@
+// Package nationalid validates Thai national ID numbers and protects them
+// at rest.
+package nationalid
+
+import (
+	"errors"
+	"strings"
+)
+
+// Length is the number of digits in a national ID.
+const Length = 13
+
+var (
+	ErrInvalidFormat   = errors.New("national ID must be 13 digits")
+	ErrInvalidChecksum = errors.New("national ID checksum does not match")
+)
+
+// Normalize removes the dashes and spaces of the printed form
+// "1-2345-67890-12-1".
+func Normalize(id string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(id))
+}
+
+// Validate checks a normalized ID. The last digit is a mod-11 check digit
+// over the first twelve, weighted 13 down to 2.
+func Validate(id string) error {
+	if len(id) != Length {
+		return ErrInvalidFormat
+	}
+	sum := 0
+	for i, c := range id {
+		if c < '0' || c > '9' {
+			return ErrInvalidFormat
+		}
+		if i < Length-1 {
+			sum += int(c-'0') * (Length - i)
+		}
+	}
+	if check := (11 - sum%11) % 10; check != int(id[Length-1]-'0') {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+// Mask hides all but the last four digits, for display.
+func Mask(id string) string {
+	if len(id) <= 4 {
+		return id
+	}
+	return strings.Repeat("X", len(id)-4) + id[len(id)-4:]
+}
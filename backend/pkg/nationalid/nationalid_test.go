@@ -0,0 +1,81 @@
+package nationalid
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const (
+	testEncryptionKey = "ZBnuwcjoHClycegcsXwqQtqya7QvoEN4Z0U8Zy7K4/8="
+	testIndexKey      = "M3B6V2BzyUluf/YGIVpXhL29p93sfrhGpYpB2refa+8="
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		id       string
+		expected error
+	}{
+		{"1101700156494", nil},
+		{"3100600235410", nil},
+		{Normalize("1-1017-00156-49-4"), nil},
+		{"1101700156495", ErrInvalidChecksum},
+		{"110170015649", ErrInvalidFormat},
+		{"11017001564a4", ErrInvalidFormat},
+	}
+
+	for _, c := range cases {
+		// Assert
+		assert.Equal(t, c.expected, Validate(c.id), c.id)
+	}
+}
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "XXXXXXXXX6494", Mask("1101700156494"))
+}
+
+func TestProtector_SealOpen(t *testing.T) {
+	p, err := NewProtector(testEncryptionKey, testIndexKey, false, true)
+	assert.NilError(t, err)
+
+	first, err := p.Seal("1101700156494")
+	assert.NilError(t, err)
+	second, err := p.Seal("1101700156494")
+	assert.NilError(t, err)
+
+	opened, err := p.Open(first)
+	assert.NilError(t, err)
+
+	first[len(first)-1] ^= 1
+	_, tamperedErr := p.Open(first)
+
+	// Assert
+	assert.Equal(t, "1101700156494", opened)
+	assert.Assert(t, string(first) != string(second))
+	assert.Equal(t, ErrCiphertext, tamperedErr)
+	assert.Equal(t, p.Index("1101700156494"), p.Index("1101700156494"))
+	assert.Assert(t, p.Index("1101700156494") != p.Index("3100600235410"))
+}
+
+func TestNewProtector_RejectsBadKeys(t *testing.T) {
+	_, shortErr := NewProtector("c2hvcnQ=", testIndexKey, false, true)
+	_, sameErr := NewProtector(testIndexKey, testIndexKey, false, true)
+	_, halfErr := NewProtector(testEncryptionKey, "", false, true)
+	_, missingErr := NewProtector("", "", true, true)
+	_, devErr := NewProtector(devKeys[0], devKeys[1], true, false)
+	_, devOptionalErr := NewProtector(devKeys[0], devKeys[1], false, false)
+	none, noneErr := NewProtector("", "", false, false)
+	dev, devAllowedErr := NewProtector(devKeys[0], devKeys[1], true, true)
+
+	// Assert
+	assert.ErrorContains(t, shortErr, "must be 32 bytes")
+	assert.ErrorContains(t, sameErr, "must differ")
+	assert.ErrorContains(t, halfErr, "must both be set")
+	assert.ErrorContains(t, missingErr, "must both be set")
+	assert.ErrorContains(t, devErr, "development keys")
+	assert.ErrorContains(t, devOptionalErr, "development keys")
+	assert.NilError(t, noneErr)
+	assert.Assert(t, none == nil)
+	assert.NilError(t, devAllowedErr)
+	assert.Assert(t, dev != nil)
+}
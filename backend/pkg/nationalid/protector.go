@@ -0,0 +1,113 @@
+package nationalid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// KeySize is the length in bytes of both keys.
+const KeySize = 32
+
+var ErrCiphertext = errors.New("national ID ciphertext is malformed")
+
+// devKeys are the keys committed in configs/config.yaml for local
+// development. Anything they protect is readable by whoever has the repo.
+var devKeys = []string{
+	"ZBnuwcjoHClycegcsXwqQtqya7QvoEN4Z0U8Zy7K4/8=",
+	"M3B6V2BzyUluf/YGIVpXhL29p93sfrhGpYpB2refa+8=",
+}
+
+// Protector encrypts IDs for storage and derives the index they are looked
+// up by. The index is keyed so the 13-digit space cannot be enumerated from
+// the database alone.
+type Protector struct {
+	aead     cipher.AEAD
+	indexKey []byte
+}
+
+// NewProtector takes base64-encoded 32-byte keys. The two keys must differ.
+// Without keys it returns a nil Protector, so national IDs are refused,
+// unless required is set. The development keys are refused unless
+// allowDevKeys is set, whether or not IDs are required.
+func NewProtector(encryptionKey, indexKey string, required, allowDevKeys bool) (*Protector, error) {
+	if encryptionKey == "" && indexKey == "" && !required {
+		return nil, nil
+	}
+	if encryptionKey == "" || indexKey == "" {
+		return nil, errors.New("national ID encryption and index keys must both be set")
+	}
+	if !allowDevKeys && (slices.Contains(devKeys, encryptionKey) || slices.Contains(devKeys, indexKey)) {
+		return nil, errors.New("national ID keys are the development keys; set NATIONAL_ID_ENCRYPTION_KEY and NATIONAL_ID_INDEX_KEY")
+	}
+
+	encKey, err := decodeKey("encryption", encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	idxKey, err := decodeKey("index", indexKey)
+	if err != nil {
+		return nil, err
+	}
+	if hmac.Equal(encKey, idxKey) {
+		return nil, errors.New("national ID encryption and index keys must differ")
+	}
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Protector{aead: aead, indexKey: idxKey}, nil
+}
+
+func decodeKey(name, key string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("national ID %s key: %w", name, err)
+	}
+	if len(b) != KeySize {
+		return nil, fmt.Errorf("national ID %s key must be %d bytes, got %d", name, KeySize, len(b))
+	}
+	return b, nil
+}
+
+// Seal encrypts id with AES-256-GCM. The random nonce is prepended to the
+// ciphertext.
+func (p *Protector) Seal(id string) ([]byte, error) {
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return p.aead.Seal(nonce, nonce, []byte(id), nil), nil
+}
+
+func (p *Protector) Open(sealed []byte) (string, error) {
+	n := p.aead.NonceSize()
+	if len(sealed) < n {
+		return "", ErrCiphertext
+	}
+	id, err := p.aead.Open(nil, sealed[:n], sealed[n:], nil)
+	if err != nil {
+		return "", ErrCiphertext
+	}
+	return string(id), nil
+}
+
+// Index is the HMAC-SHA256 of id in hex, stable across calls so equal IDs
+// can be found without decrypting.
+func (p *Protector) Index(id string) string {
+	mac := hmac.New(sha256.New, p.indexKey)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,31 @@
+// Package notify delivers messages to applicants by email and SMS.
+package notify
+
+import "context"
+
+// Email is a plain-text email. To is a bare address.
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// EmailSender delivers email. An error means the message was not accepted
+// and may be sent again later.
+type EmailSender interface {
+	Name() string
+	SendEmail(ctx context.Context, email Email) error
+}
+
+// SMS is a text message. To is an E.164 number, e.g. "+66851234567".
+type SMS struct {
+	To   string
+	Text string
+}
+
+// SMSSender hands text messages to an SMS provider. An error means the
+// message was not accepted and may be sent again later.
+type SMSSender interface {
+	Name() string
+	SendSMS(ctx context.Context, sms SMS) error
+}
@@ -0,0 +1,186 @@
+package notify
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+// fakeSMTP is a stand-in for an SMTP server that keeps the messages it
+// receives. It accepts AUTH PLAIN for test-user/test-pass and refuses
+// recipients at bounce.example.
+type fakeSMTP struct {
+	mu       sync.Mutex
+	messages []received
+}
+
+type received struct {
+	auth string
+	from string
+	to   string
+	data string
+}
+
+func serveSMTP(t *testing.T) (*fakeSMTP, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	f := &fakeSMTP{}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f, listener.Addr().String()
+}
+
+func (f *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	c := textproto.NewConn(conn)
+	c.PrintfLine("220 fake ESMTP")
+
+	var msg received
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			c.PrintfLine("250-fake")
+			c.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(arg, "PLAIN "))
+			if string(credentials) != "\x00test-user\x00test-pass" {
+				c.PrintfLine("535 authentication failed")
+				continue
+			}
+			msg.auth = "test-user"
+			c.PrintfLine("235 ok")
+		case "MAIL":
+			msg.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			c.PrintfLine("250 ok")
+		case "RCPT":
+			msg.to = strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			if strings.HasSuffix(msg.to, "@bounce.example") {
+				c.PrintfLine("550 no such user")
+				continue
+			}
+			c.PrintfLine("250 ok")
+		case "DATA":
+			c.PrintfLine("354 go ahead")
+			data, err := io.ReadAll(c.DotReader())
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			f.mu.Lock()
+			f.messages = append(f.messages, msg)
+			f.mu.Unlock()
+			c.PrintfLine("250 queued")
+		case "RSET":
+			msg = received{}
+			c.PrintfLine("250 ok")
+		case "QUIT":
+			c.PrintfLine("221 bye")
+			return
+		default:
+			c.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func TestSMTPSender(t *testing.T) {
+	ctx := context.Background()
+	server, address := serveSMTP(t)
+
+	sender, err := NewSMTPSender(SMTPOptions{
+		Address:  address,
+		Username: "test-user",
+		Password: "test-pass",
+		From:     "Loans <loans@example.com>",
+		Timeout:  5 * time.Second,
+	})
+	assert.NilError(t, err)
+
+	sendErr := sender.SendEmail(ctx, Email{
+		To:      "somkanit@example.com",
+		Subject: "ผลการพิจารณาสินเชื่อ",
+		Body:    "เรียน คุณสมคนิต\n\nใบสมัครของท่านได้รับการอนุมัติแล้ว\n",
+	})
+	bounced := sender.SendEmail(ctx, Email{To: "nobody@bounce.example", Subject: "x", Body: "x"})
+	invalid := sender.SendEmail(ctx, Email{To: "not an address", Subject: "x", Body: "x"})
+
+	assert.Equal(t, 1, len(server.messages))
+	got := server.messages[0]
+	message, err := mail.ReadMessage(strings.NewReader(got.data))
+	assert.NilError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	assert.NilError(t, err)
+	body, err := io.ReadAll(quotedprintable.NewReader(message.Body))
+	assert.NilError(t, err)
+
+	// Assert
+	assert.NilError(t, sendErr)
+	assert.Equal(t, "test-user", got.auth)
+	assert.Equal(t, "loans@example.com", got.from)
+	assert.Equal(t, "somkanit@example.com", got.to)
+	assert.Equal(t, "ผลการพิจารณาสินเชื่อ", subject)
+	assert.Equal(t, "text/plain; charset=UTF-8", message.Header.Get("Content-Type"))
+	assert.Equal(t, "เรียน คุณสมคนิต\n\nใบสมัครของท่านได้รับการอนุมัติแล้ว\n", string(body))
+	assert.ErrorContains(t, bounced, "no such user")
+	assert.ErrorContains(t, invalid, "invalid recipient")
+}
+
+func TestHTTPSMS(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	sent := []map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != messagesPath || r.Header.Get("Authorization") != "Bearer test-key" {
+			http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		sent = append(sent, payload)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sendErr := NewHTTPSMS(server.URL+"/", "test-key", server.Client()).SendSMS(ctx, SMS{To: "+66851234567", Text: "อนุมัติแล้ว"})
+	denied := NewHTTPSMS(server.URL, "other-key", server.Client()).SendSMS(ctx, SMS{To: "+66851234567", Text: "x"})
+
+	// Assert
+	assert.NilError(t, sendErr)
+	assert.DeepEqual(t, []map[string]string{{"to": "+66851234567", "text": "อนุมัติแล้ว"}}, sent)
+	assert.ErrorContains(t, denied, "401 Unauthorized")
+}
+
+func TestMaskNumber(t *testing.T) {
+	// Assert
+	assert.Equal(t, "********4567", maskNumber("+66851234567"))
+	assert.Equal(t, "123", maskNumber("123"))
+}
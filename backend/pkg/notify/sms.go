@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const messagesPath = "/v1/messages"
+
+// HTTPSMS posts each message as {"to": ..., "text": ...} to
+// {baseURL}/v1/messages of an SMS gateway. Any 2xx status means the gateway
+// accepted the message. apiKey, when set, is sent as a bearer token.
+type HTTPSMS struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewHTTPSMS(baseURL string, apiKey string, client *http.Client) *HTTPSMS {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPSMS{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  client,
+	}
+}
+
+func (p *HTTPSMS) Name() string {
+	return "http"
+}
+
+func (p *HTTPSMS) SendSMS(ctx context.Context, sms SMS) error {
+	body, err := json.Marshal(map[string]string{"to": sms.To, "text": sms.Text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+messagesPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		reply, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("sms gateway: %s: %s", resp.Status, strings.TrimSpace(string(reply)))
+	}
+	return nil
+}
+
+// LogSMS writes messages to the log instead of sending them. It stands in
+// for a provider in local development.
+type LogSMS struct{}
+
+func (LogSMS) Name() string {
+	return "log"
+}
+
+func (LogSMS) SendSMS(ctx context.Context, sms SMS) error {
+	log.Printf("sms to %s: %s", maskNumber(sms.To), sms.Text)
+	return nil
+}
+
+// maskNumber keeps the last four digits of a phone number.
+func maskNumber(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	return strings.Repeat("*", len(number)-4) + number[len(number)-4:]
+}
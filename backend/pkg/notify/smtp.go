@@ -0,0 +1,150 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SMTPOptions configure an SMTPSender. Address is the server's
+// "{host}:{port}". From is the sender, e.g. "Loans <loans@example.com>".
+// Username may be left empty for a relay that needs no login.
+type SMTPOptions struct {
+	Address  string
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// SMTPSender sends each email over its own SMTP connection, upgrading it
+// with STARTTLS whenever the server offers it.
+type SMTPSender struct {
+	options SMTPOptions
+	host    string
+	from    *mail.Address
+	now     func() time.Time
+}
+
+func NewSMTPSender(options SMTPOptions) (*SMTPSender, error) {
+	host, _, err := net.SplitHostPort(options.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q: %w", options.Address, err)
+	}
+	from, err := mail.ParseAddress(options.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP sender %q: %w", options.From, err)
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 30 * time.Second
+	}
+	return &SMTPSender{
+		options: options,
+		host:    host,
+		from:    from,
+		now:     time.Now,
+	}, nil
+}
+
+func (s *SMTPSender) Name() string {
+	return "smtp"
+}
+
+func (s *SMTPSender) SendEmail(ctx context.Context, email Email) error {
+	to, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", email.To, err)
+	}
+	message, err := s.message(to, email)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.options.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.options.Address)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.options.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.options.Username, s.options.Password, s.host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// message formats email as a UTF-8 plain-text message. Headers are encoded
+// as RFC 2047 words and the body as quoted-printable, so Thai text survives
+// servers that only pass 7-bit ASCII.
+func (s *SMTPSender) message(to *mail.Address, email Email) ([]byte, error) {
+	domain := s.from.Address[strings.LastIndexByte(s.from.Address, '@')+1:]
+
+	var b bytes.Buffer
+	header := func(name string, value string) {
+		b.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", s.from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("UTF-8", email.Subject))
+	header("Date", s.now().Format(time.RFC1123Z))
+	header("Message-ID", "<"+uuid.New().String()+"@"+domain+">")
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=UTF-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	b.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&b)
+	if _, err := w.Write([]byte(email.Body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
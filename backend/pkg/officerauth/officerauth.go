@@ -0,0 +1,87 @@
+// Package officerauth identifies underwriting officers by bearer token, so
+// officer routes act for the officer the token was issued to rather than an
+// id the caller names.
+package officerauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const contextKey = "officerauth.officerId"
+
+// Tokens maps the SHA-256 digest of each officer's token to the officer.
+type Tokens map[[sha256.Size]byte]string
+
+// ParseTokens reads "officer-17:token,officer-22:token" as configured in
+// officers.tokens or OFFICER_TOKENS. Officer ids and tokens must not be
+// empty and a token may only be issued once.
+func ParseTokens(s string) (Tokens, error) {
+	tokens := Tokens{}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		officerId, token, ok := strings.Cut(entry, ":")
+		officerId, token = strings.TrimSpace(officerId), strings.TrimSpace(token)
+		if !ok || officerId == "" || token == "" {
+			return nil, errors.New("officer token entries must be officerId:token")
+		}
+		digest := sha256.Sum256([]byte(token))
+		if _, taken := tokens[digest]; taken {
+			return nil, fmt.Errorf("officer token for %q is issued more than once", officerId)
+		}
+		tokens[digest] = officerId
+	}
+	return tokens, nil
+}
+
+// Required rejects requests without a known "Authorization: Bearer" token
+// with 401 and records the officer for OfficerId otherwise. With no tokens
+// configured every request is rejected.
+func Required(tokens Tokens) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		officerId := tokens.lookup(c.GetHeader("Authorization"))
+		if officerId == "" {
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"message": "Authentication required",
+				"reason":  "a valid officer bearer token is required",
+			})
+			return
+		}
+		c.Set(contextKey, officerId)
+		c.Next()
+	}
+}
+
+// lookup compares the digest of the presented token with every issued one,
+// so the time taken does not tell how close a guess was.
+func (t Tokens) lookup(header string) string {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return ""
+	}
+	digest := sha256.Sum256([]byte(strings.TrimSpace(token)))
+
+	found := ""
+	for issued, officerId := range t {
+		if subtle.ConstantTimeCompare(digest[:], issued[:]) == 1 {
+			found = officerId
+		}
+	}
+	return found
+}
+
+// OfficerId is the officer authenticated by Required, or "" on a route
+// without it.
+func OfficerId(c *gin.Context) string {
+	return c.GetString(contextKey)
+}
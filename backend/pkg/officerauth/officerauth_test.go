@@ -0,0 +1,64 @@
+package officerauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gotest.tools/assert"
+)
+
+func TestParseTokens(t *testing.T) {
+	tokens, err := ParseTokens(" officer-17:token-a, officer-22:token-b ,")
+	_, malformedErr := ParseTokens("officer-17")
+	_, emptyErr := ParseTokens("officer-17:")
+	_, reusedErr := ParseTokens("officer-17:token-a,officer-22:token-a")
+
+	// Assert
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(tokens))
+	assert.ErrorContains(t, malformedErr, "must be officerId:token")
+	assert.ErrorContains(t, emptyErr, "must be officerId:token")
+	assert.ErrorContains(t, reusedErr, "more than once")
+}
+
+func TestRequired(t *testing.T) {
+	tokens, err := ParseTokens("officer-17:token-a")
+	assert.NilError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/officer", Required(tokens), func(c *gin.Context) {
+		c.String(http.StatusOK, OfficerId(c))
+	})
+	r.GET("/none", Required(Tokens{}), func(c *gin.Context) {
+		c.String(http.StatusOK, OfficerId(c))
+	})
+	send := func(path string, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://0.0.0.0"+path, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	ok := send("/officer", "Bearer token-a")
+	lowercase := send("/officer", "bearer token-a")
+	wrong := send("/officer", "Bearer token-b")
+	missing := send("/officer", "")
+	basic := send("/officer", "Basic token-a")
+	unconfigured := send("/none", "Bearer token-a")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, ok.Code)
+	assert.Equal(t, "officer-17", ok.Body.String())
+	assert.Equal(t, http.StatusOK, lowercase.Code)
+	assert.Equal(t, http.StatusUnauthorized, wrong.Code)
+	assert.Equal(t, "Bearer", wrong.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, http.StatusUnauthorized, missing.Code)
+	assert.Equal(t, http.StatusUnauthorized, basic.Code)
+	assert.Equal(t, http.StatusUnauthorized, unconfigured.Code)
+}
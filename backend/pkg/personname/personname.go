@@ -0,0 +1,80 @@
+// Package personname normalizes and checks applicant names written in Thai
+// or Latin script.
+package personname
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+type Script string
+
+const (
+	Thai  Script = "thai"
+	Latin Script = "latin"
+)
+
+var (
+	ErrInvalidCharacter = errors.New("name may only contain letters, spaces, hyphens, apostrophes and periods")
+	ErrMixedScripts     = errors.New("name must be written in a single script")
+)
+
+// separators may appear between the letters of a name in either script.
+const separators = " -'’."
+
+var folder = cases.Fold()
+
+// Normalize returns name in NFC with surrounding whitespace removed and
+// inner runs of whitespace collapsed to a single space. Lengths and scripts
+// are checked on the normalized form.
+func Normalize(name string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+}
+
+// Length counts the characters of name, not its UTF-8 bytes.
+func Length(name string) int {
+	return utf8.RuneCountInString(name)
+}
+
+// MatchKey is the form names are compared in: normalized and case-folded,
+// so "  SOMKANIT  jitsanook" and "Somkanit Jitsanook" share a key.
+func MatchKey(name string) string {
+	return norm.NFC.String(folder.String(Normalize(name)))
+}
+
+// DetectScript reports the script of a normalized name. Combining marks
+// count towards the script of the letter they follow.
+func DetectScript(name string) (Script, error) {
+	var script Script
+	for _, r := range name {
+		var s Script
+		switch {
+		case strings.ContainsRune(separators, r):
+			continue
+		case unicode.IsMark(r):
+			if script == "" {
+				return "", ErrInvalidCharacter
+			}
+			continue
+		case unicode.IsLetter(r) && unicode.Is(unicode.Thai, r):
+			s = Thai
+		case unicode.IsLetter(r) && unicode.Is(unicode.Latin, r):
+			s = Latin
+		default:
+			return "", ErrInvalidCharacter
+		}
+		if script != "" && script != s {
+			return "", ErrMixedScripts
+		}
+		script = s
+	}
+	if script == "" {
+		return "", ErrInvalidCharacter
+	}
+	return script, nil
+}
@@ -0,0 +1,54 @@
+package personname
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	// "e" followed by a combining acute accent composes to "é".
+	assert.Equal(t, "Ren\u00e9e Dupont", Normalize("  Rene\u0301e \t Dupont "))
+	assert.Equal(t, "สมคิด ใจดี", Normalize("สมคิด   ใจดี"))
+}
+
+func TestLength_CountsCharacters(t *testing.T) {
+	name := "สมคิด"
+
+	// Assert
+	assert.Equal(t, 15, len(name))
+	assert.Equal(t, 5, Length(name))
+}
+
+func TestMatchKey(t *testing.T) {
+	assert.Equal(t, MatchKey("Somkanit Jitsanook"), MatchKey("  SOMKANIT  jitsanook"))
+	assert.Equal(t, MatchKey("Renée"), MatchKey("RENÉE"))
+	assert.Equal(t, "สมคิด ใจดี", MatchKey("สมคิด  ใจดี"))
+}
+
+func TestDetectScript(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected Script
+		err      error
+	}{
+		{"Somkanit Jitsanook", Latin, nil},
+		{"Anne-Marie O'Neil", Latin, nil},
+		{"Rene\u0301e", Latin, nil},
+		{"สมคิด ใจดี", Thai, nil},
+		{"ศักดิ์สิทธิ์", Thai, nil},
+		{"Somkanit ใจดี", "", ErrMixedScripts},
+		{"John Smith 3rd", "", ErrInvalidCharacter},
+		{"John@Smith", "", ErrInvalidCharacter},
+		{"\u0301abc", "", ErrInvalidCharacter},
+		{" - ", "", ErrInvalidCharacter},
+	}
+
+	for _, c := range cases {
+		script, err := DetectScript(c.name)
+
+		// Assert
+		assert.Equal(t, c.expected, script, c.name)
+		assert.Equal(t, c.err, err, c.name)
+	}
+}
@@ -0,0 +1,89 @@
+// Package phone parses applicant phone numbers in any common format and
+// normalizes them to E.164.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ttacon/libphonenumber"
+)
+
+type Type string
+
+// Number types. Some countries, e.g. the US, do not distinguish mobile from
+// landline numbers; those are TypeMobileOrLandline.
+const (
+	TypeMobile           Type = "mobile"
+	TypeLandline         Type = "landline"
+	TypeMobileOrLandline Type = "mobileOrLandline"
+	TypeOther            Type = "other"
+)
+
+var ErrInvalidNumber = errors.New("phone number is not valid")
+
+// Number is a parsed phone number. Display is the input as the applicant
+// wrote it; E164 is the form numbers are stored and matched in.
+type Number struct {
+	E164    string
+	Region  string
+	Type    Type
+	Display string
+}
+
+var defaultRegion atomic.Value
+
+func init() {
+	defaultRegion.Store("TH")
+}
+
+// SetDefaultRegion sets the ISO 3166 region assumed for numbers written
+// without a country code, such as "0851234567".
+func SetDefaultRegion(region string) error {
+	region = strings.ToUpper(region)
+	if libphonenumber.GetCountryCodeForRegion(region) == 0 {
+		return fmt.Errorf("unsupported phone region %q", region)
+	}
+	defaultRegion.Store(region)
+	return nil
+}
+
+func DefaultRegion() string {
+	return defaultRegion.Load().(string)
+}
+
+// Parse reads input in the default region. Numbers with a leading "+" may
+// belong to any region.
+func Parse(input string) (Number, error) {
+	return ParseIn(input, DefaultRegion())
+}
+
+func ParseIn(input string, region string) (Number, error) {
+	display := strings.TrimSpace(input)
+	n, err := libphonenumber.Parse(display, region)
+	if err != nil || !libphonenumber.IsValidNumber(n) {
+		return Number{}, ErrInvalidNumber
+	}
+
+	return Number{
+		E164:    libphonenumber.Format(n, libphonenumber.E164),
+		Region:  libphonenumber.GetRegionCodeForNumber(n),
+		Type:    numberType(libphonenumber.GetNumberType(n)),
+		Display: display,
+	}, nil
+}
+
+func numberType(t libphonenumber.PhoneNumberType) Type {
+	switch t {
+	case libphonenumber.MOBILE:
+		return TypeMobile
+	case libphonenumber.FIXED_LINE:
+		return TypeLandline
+	case libphonenumber.FIXED_LINE_OR_MOBILE:
+		return TypeMobileOrLandline
+	default:
+		return TypeOther
+	}
+}
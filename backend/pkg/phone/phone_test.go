@@ -0,0 +1,60 @@
+package phone
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input    string
+		e164     string
+		region   string
+		kind     Type
+		expected error
+	}{
+		{"0851234567", "+66851234567", "TH", TypeMobile, nil},
+		{"085-123-4567", "+66851234567", "TH", TypeMobile, nil},
+		{"+66851234567", "+66851234567", "TH", TypeMobile, nil},
+		{"02 123 4567", "+6621234567", "TH", TypeLandline, nil},
+		{"+44 20 7946 0958", "+442079460958", "GB", TypeLandline, nil},
+		{"+1 415-555-2671", "+14155552671", "US", TypeMobileOrLandline, nil},
+		{"12345", "", "", "", ErrInvalidNumber},
+		{"phone", "", "", "", ErrInvalidNumber},
+	}
+
+	for _, c := range cases {
+		n, err := Parse(c.input)
+
+		// Assert
+		assert.Equal(t, c.expected, err, c.input)
+		assert.Equal(t, c.e164, n.E164, c.input)
+		assert.Equal(t, c.region, n.Region, c.input)
+		assert.Equal(t, c.kind, n.Type, c.input)
+	}
+}
+
+func TestParse_KeepsDisplayFormat(t *testing.T) {
+	n, err := Parse(" 085-123-4567 ")
+	assert.NilError(t, err)
+
+	// Assert
+	assert.Equal(t, "085-123-4567", n.Display)
+}
+
+func TestParseIn_OtherRegion(t *testing.T) {
+	n, err := ParseIn("020 7946 0958", "GB")
+	assert.NilError(t, err)
+
+	// Assert
+	assert.Equal(t, "+442079460958", n.E164)
+}
+
+func TestSetDefaultRegion_RejectsUnknown(t *testing.T) {
+	err := SetDefaultRegion("XX")
+
+	// Assert
+	assert.ErrorContains(t, err, "unsupported phone region")
+	assert.Equal(t, "TH", DefaultRegion())
+}
@@ -0,0 +1,208 @@
+package scorecard
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/viper"
+)
+
+// Outcomes a grade maps to.
+const (
+	OutcomeApprove = "approve"
+	OutcomeRefer   = "refer"
+	OutcomeDecline = "decline"
+)
+
+var ErrInvalidScorecard = errors.New("invalid scorecard")
+
+// Bin awards Points when a characteristic's value falls in it. Numeric bins
+// are [Min, Max) with either bound optional; categorical bins list Values.
+// A Missing bin matches when the applicant has no value, e.g. no credit
+// history.
+type Bin struct {
+	Min     *float64 `mapstructure:"min"`
+	Max     *float64 `mapstructure:"max"`
+	Values  []string `mapstructure:"values"`
+	Missing bool     `mapstructure:"missing"`
+	Points  int      `mapstructure:"points"`
+}
+
+type Characteristic struct {
+	Name string `mapstructure:"name"`
+	Bins []Bin  `mapstructure:"bins"`
+}
+
+// Grade applies to scores of at least MinScore.
+type Grade struct {
+	Grade    string `mapstructure:"grade"`
+	MinScore int    `mapstructure:"min_score"`
+}
+
+// Scorecard is a versioned points model. The score is BasePoints plus the
+// points of the first matching bin of every characteristic; grades not listed
+// in the approve or refer cutoffs decline.
+type Scorecard struct {
+	Version         string           `mapstructure:"version"`
+	BasePoints      int              `mapstructure:"base_points"`
+	Characteristics []Characteristic `mapstructure:"characteristics"`
+	Grades          []Grade          `mapstructure:"grades"`
+	Cutoffs         struct {
+		Approve []string `mapstructure:"approve"`
+		Refer   []string `mapstructure:"refer"`
+	} `mapstructure:"cutoffs"`
+}
+
+// Attributes are the applicant's values by characteristic name. A name
+// absent from both maps is missing.
+type Attributes struct {
+	Numeric     map[string]float64
+	Categorical map[string]string
+}
+
+// Points is one characteristic's contribution to a score.
+type Points struct {
+	Characteristic string `json:"characteristic"`
+	Value          string `json:"value"`
+	Points         int    `json:"points"`
+}
+
+type Result struct {
+	Version string   `json:"version"`
+	Score   int      `json:"score"`
+	Grade   string   `json:"grade"`
+	Outcome string   `json:"outcome"`
+	Points  []Points `json:"points"`
+}
+
+func LoadFile(path string) (*Scorecard, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	var sc Scorecard
+	if err := v.Unmarshal(&sc); err != nil {
+		return nil, err
+	}
+	if err := sc.Validate(); err != nil {
+		return nil, fmt.Errorf("scorecard %s: %w", path, err)
+	}
+	return &sc, nil
+}
+
+// Validate checks the scorecard is usable and sorts its grades from the
+// highest cutoff down.
+func (sc *Scorecard) Validate() error {
+	if sc.Version == "" {
+		return fmt.Errorf("%w: missing version", ErrInvalidScorecard)
+	}
+	if len(sc.Grades) == 0 {
+		return fmt.Errorf("%w: no grades", ErrInvalidScorecard)
+	}
+	for _, c := range sc.Characteristics {
+		if len(c.Bins) == 0 {
+			return fmt.Errorf("%w: characteristic %s has no bins", ErrInvalidScorecard, c.Name)
+		}
+	}
+
+	known := map[string]bool{}
+	for _, g := range sc.Grades {
+		known[g.Grade] = true
+	}
+	for _, g := range append(append([]string{}, sc.Cutoffs.Approve...), sc.Cutoffs.Refer...) {
+		if !known[g] {
+			return fmt.Errorf("%w: cutoff grade %s is not defined", ErrInvalidScorecard, g)
+		}
+	}
+
+	sort.SliceStable(sc.Grades, func(i, j int) bool {
+		return sc.Grades[i].MinScore > sc.Grades[j].MinScore
+	})
+	return nil
+}
+
+func (sc *Scorecard) Score(attrs Attributes) Result {
+	res := Result{Version: sc.Version, Score: sc.BasePoints}
+
+	for _, c := range sc.Characteristics {
+		p := Points{Characteristic: c.Name}
+		if n, ok := attrs.Numeric[c.Name]; ok {
+			p.Value = strconv.FormatFloat(n, 'f', -1, 64)
+			p.Points = numericPoints(c.Bins, n)
+		} else if s, ok := attrs.Categorical[c.Name]; ok {
+			p.Value = s
+			p.Points = categoricalPoints(c.Bins, s)
+		} else {
+			p.Points = missingPoints(c.Bins)
+		}
+		res.Points = append(res.Points, p)
+		res.Score += p.Points
+	}
+
+	res.Grade = sc.Grades[len(sc.Grades)-1].Grade
+	for _, g := range sc.Grades {
+		if res.Score >= g.MinScore {
+			res.Grade = g.Grade
+			break
+		}
+	}
+
+	res.Outcome = OutcomeDecline
+	if contains(sc.Cutoffs.Approve, res.Grade) {
+		res.Outcome = OutcomeApprove
+	} else if contains(sc.Cutoffs.Refer, res.Grade) {
+		res.Outcome = OutcomeRefer
+	}
+	return res
+}
+
+func numericPoints(bins []Bin, n float64) int {
+	for _, b := range bins {
+		if b.Missing || len(b.Values) > 0 {
+			continue
+		}
+		if (b.Min == nil || n >= *b.Min) && (b.Max == nil || n < *b.Max) {
+			return b.Points
+		}
+	}
+	return 0
+}
+
+func categoricalPoints(bins []Bin, s string) int {
+	var fallback *Bin
+	for i, b := range bins {
+		if contains(b.Values, s) {
+			return b.Points
+		}
+		// A bin with no values or bounds catches every other category.
+		if !b.Missing && len(b.Values) == 0 && b.Min == nil && b.Max == nil && fallback == nil {
+			fallback = &bins[i]
+		}
+	}
+	if fallback != nil {
+		return fallback.Points
+	}
+	return 0
+}
+
+func missingPoints(bins []Bin) int {
+	for _, b := range bins {
+		if b.Missing {
+			return b.Points
+		}
+	}
+	return 0
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package scorecard
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestLoadFile_ShippedScorecard(t *testing.T) {
+	sc, err := LoadFile("../../configs/scorecard.yaml")
+	assert.NilError(t, err)
+
+	res := sc.Score(Attributes{
+		Numeric: map[string]float64{
+			"monthlyIncome": 40000,
+			"age":           38,
+			"loanToIncome":  2.5,
+			"creditScore":   730,
+		},
+		Categorical: map[string]string{"purpose": "home"},
+	})
+
+	// Assert: 300 + 40 + 35 + 40 + 45 + 15 (no term) + 45
+	assert.Equal(t, 520, res.Score)
+	assert.Equal(t, "A", res.Grade)
+	assert.Equal(t, OutcomeApprove, res.Outcome)
+	assert.Equal(t, 6, len(res.Points))
+}
+
+func TestScore_GradesAndCutoffs(t *testing.T) {
+	ten := 10.0
+	sc := &Scorecard{
+		Version:    "test",
+		BasePoints: 100,
+		Characteristics: []Characteristic{
+			{Name: "age", Bins: []Bin{{Max: &ten, Points: 0}, {Min: &ten, Points: 20}}},
+			{Name: "purpose", Bins: []Bin{{Values: []string{"home"}, Points: 30}, {Points: 5}}},
+		},
+		Grades: []Grade{{Grade: "C", MinScore: 0}, {Grade: "A", MinScore: 140}, {Grade: "B", MinScore: 120}},
+	}
+	sc.Cutoffs.Approve = []string{"A"}
+	sc.Cutoffs.Refer = []string{"B"}
+	assert.NilError(t, sc.Validate())
+
+	approve := sc.Score(Attributes{Numeric: map[string]float64{"age": 30}, Categorical: map[string]string{"purpose": "home"}})
+	refer := sc.Score(Attributes{Numeric: map[string]float64{"age": 30}, Categorical: map[string]string{"purpose": "car"}})
+	decline := sc.Score(Attributes{Categorical: map[string]string{"purpose": "car"}})
+
+	// Assert
+	assert.Equal(t, OutcomeApprove, approve.Outcome)
+	assert.Equal(t, 125, refer.Score)
+	assert.Equal(t, OutcomeRefer, refer.Outcome)
+	assert.Equal(t, "C", decline.Grade)
+	assert.Equal(t, OutcomeDecline, decline.Outcome)
+}
+
+func TestValidate_UnknownCutoffGrade(t *testing.T) {
+	sc := &Scorecard{Version: "test", Grades: []Grade{{Grade: "A"}}}
+	sc.Cutoffs.Approve = []string{"Z"}
+
+	// Assert
+	assert.ErrorContains(t, sc.Validate(), "cutoff grade Z is not defined")
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"backend-loan-pre-approval/pkg/creditbureau"
+	"backend-loan-pre-approval/pkg/database"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/types"
+)
+
+// CreditReport is a row of the credit_reports table.
+type CreditReport struct {
+	ApplicationId string             `db:"application_id"`
+	Provider      string             `db:"provider"`
+	Status        string             `db:"status"`
+	Score         sql.NullInt32      `db:"score"`
+	Report        types.NullJSONText `db:"report"`
+	RetrievedAt   time.Time          `db:"retrieved_at"`
+}
+
+func NewCreditReport(applicationId string, r creditbureau.Report) CreditReport {
+	row := CreditReport{
+		ApplicationId: applicationId,
+		Provider:      r.Provider,
+		Status:        r.Status,
+		RetrievedAt:   r.RetrievedAt,
+	}
+	if r.Status == creditbureau.StatusHit {
+		row.Score = sql.NullInt32{Int32: int32(r.Score), Valid: true}
+	}
+	if len(r.Raw) > 0 && json.Valid(r.Raw) {
+		row.Report = types.NullJSONText{JSONText: types.JSONText(r.Raw), Valid: true}
+	}
+	return row
+}
+
+func (e CreditReport) ToReport() creditbureau.Report {
+	return creditbureau.Report{
+		Provider:    e.Provider,
+		Status:      e.Status,
+		Score:       int(e.Score.Int32),
+		RetrievedAt: e.RetrievedAt,
+		Raw:         json.RawMessage(e.Report.JSONText),
+	}
+}
+
+var creditReportColumns = []string{
+	"application_id",
+	"provider",
+	"status",
+	"score",
+	"report",
+	"retrieved_at",
+}
+
+var (
+	selectCreditReportColumns = strings.Join(creditReportColumns, ", ")
+
+	sqlInsertCreditReport = `INSERT INTO credit_reports (` + selectCreditReportColumns + `)
+		VALUES (:` + strings.Join(creditReportColumns, ", :") + `)`
+
+	sqlGetCreditReport = `SELECT ` + selectCreditReportColumns + `
+		FROM credit_reports WHERE application_id = $1`
+)
+
+func (q *Queries) InsertCreditReport(ctx context.Context, arg CreditReport) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlInsertCreditReport, arg)
+	return err
+}
+
+func (q *Queries) GetCreditReport(ctx context.Context, applicationId string) (CreditReport, error) {
+	var row CreditReport
+	err := database.Conn(ctx, q.db).GetContext(ctx, &row, sqlGetCreditReport, applicationId)
+	return row, err
+}
@@ -0,0 +1,290 @@
+package store
+
+import (
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/money"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/types"
+)
+
+// Application statuses. Drafts carry no decision and are not evaluated until
+// they are submitted.
+const (
+	ApplicationDraft     = "draft"
+	ApplicationSubmitted = "submitted"
+)
+
+// LoanApplication is the canonical row of the loan_applications table. Every
+// column read or written by the queries below must appear in
+// loanApplicationColumns with a matching db tag here.
+type LoanApplication struct {
+	ApplicationId         string `db:"application_id"`
+	FullName              string `db:"full_name"`
+	GivenNameTh           string `db:"given_name_th"`
+	FamilyNameTh          string `db:"family_name_th"`
+	GivenNameEn           string `db:"given_name_en"`
+	FamilyNameEn          string `db:"family_name_en"`
+	FullNameKey           string `db:"full_name_key"`
+	MonthlyIncome         int64  `db:"monthly_income"`
+	MonthlyIncomeCurrency string `db:"monthly_income_currency"`
+	LoanAmount            int64  `db:"loan_amount"`
+	LoanAmountCurrency    string `db:"loan_amount_currency"`
+	LoanPurpose           string `db:"loan_purpose"`
+	TermMonths            int    `db:"term_months"`
+	InterestRateBps       int    `db:"interest_rate_bps"`
+	Age                   int    `db:"age"`
+	PhoneNumber           string `db:"phone_number"`
+	PhoneE164             string `db:"phone_e164"`
+	PhoneRegion           string `db:"phone_region"`
+	PhoneType             string `db:"phone_type"`
+	Email                 string `db:"email"`
+
+	// Locale is the language notifications are written in; empty for
+	// applications stored before it was captured.
+	Locale string `db:"locale"`
+
+	// DateOfBirth is NULL for applications stored before it was captured,
+	// and for drafts without one.
+	DateOfBirth sql.NullTime `db:"date_of_birth"`
+
+	// Employment columns are empty when the applicant gave no employment.
+	EmploymentType         string `db:"employment_type"`
+	EmployerName           string `db:"employer_name"`
+	EmploymentTenureMonths int    `db:"employment_tenure_months"`
+	IncomeFrequency        string `db:"income_frequency"`
+
+	// The national ID is never stored in clear; see nationalid.Protector.
+	NationalIdEncrypted []byte         `db:"national_id_encrypted"`
+	NationalIdHash      sql.NullString `db:"national_id_hash"`
+
+	Timestamp time.Time `db:"timestamp"`
+	Status    string    `db:"status"`
+	Version   int       `db:"version"`
+
+	// Decision columns are NULL for applications stored before decisions
+	// were persisted.
+	Eligible sql.NullBool       `db:"eligible"`
+	Reason   sql.NullString     `db:"reason"`
+	Decision types.NullJSONText `db:"decision"`
+
+	Outcome   sql.NullString `db:"outcome"`
+	RiskScore sql.NullInt32  `db:"risk_score"`
+	RiskGrade sql.NullString `db:"risk_grade"`
+
+	Offer           types.NullJSONText `db:"offer"`
+	OfferAcceptedAt sql.NullTime       `db:"offer_accepted_at"`
+}
+
+func (e LoanApplication) Income() money.Money {
+	return money.New(e.MonthlyIncome, e.MonthlyIncomeCurrency)
+}
+
+func (e LoanApplication) Loan() money.Money {
+	return money.New(e.LoanAmount, e.LoanAmountCurrency)
+}
+
+var loanApplicationColumns = []string{
+	"application_id",
+	"full_name",
+	"given_name_th",
+	"family_name_th",
+	"given_name_en",
+	"family_name_en",
+	"full_name_key",
+	"monthly_income",
+	"monthly_income_currency",
+	"loan_amount",
+	"loan_amount_currency",
+	"loan_purpose",
+	"term_months",
+	"interest_rate_bps",
+	"age",
+	"phone_number",
+	"phone_e164",
+	"phone_region",
+	"phone_type",
+	"email",
+	"locale",
+	"date_of_birth",
+	"employment_type",
+	"employer_name",
+	"employment_tenure_months",
+	"income_frequency",
+	"national_id_encrypted",
+	"national_id_hash",
+	"timestamp",
+	"status",
+	"version",
+	"eligible",
+	"reason",
+	"decision",
+	"outcome",
+	"risk_score",
+	"risk_grade",
+	"offer",
+	"offer_accepted_at",
+}
+
+var (
+	selectLoanApplicationColumns = strings.Join(loanApplicationColumns, ", ")
+	insertLoanApplicationValues  = ":" + strings.Join(loanApplicationColumns, ", :")
+	updateLoanApplicationSet     = updateSet(loanApplicationColumns[1:])
+)
+
+func updateSet(columns []string) string {
+	set := make([]string, 0, len(columns))
+	for _, v := range columns {
+		set = append(set, v+" = :"+v)
+	}
+	return strings.Join(set, ", ")
+}
+
+var (
+	sqlInsertLoanApplication = `INSERT INTO loan_applications (` + selectLoanApplicationColumns + `)
+		VALUES (` + insertLoanApplicationValues + `)`
+
+	sqlGetLoanApplication = `SELECT ` + selectLoanApplicationColumns + `
+		FROM loan_applications WHERE application_id = $1`
+
+	sqlGetLoanApplicationForUpdate = sqlGetLoanApplication + ` FOR UPDATE`
+
+	sqlUpdateLoanApplication = `UPDATE loan_applications SET ` + updateLoanApplicationSet + `
+		WHERE application_id = :application_id`
+
+	sqlAcceptLoanOffer = `UPDATE loan_applications SET
+		loan_amount = :loan_amount,
+		loan_amount_currency = :loan_amount_currency,
+		term_months = :term_months,
+		eligible = :eligible,
+		reason = :reason,
+		decision = :decision,
+		outcome = :outcome,
+		risk_score = :risk_score,
+		risk_grade = :risk_grade,
+		offer_accepted_at = :offer_accepted_at
+		WHERE application_id = :application_id`
+
+	// Keyset pagination over (timestamp, application_id) keeps batches
+	// stable while rows are added during a long scan.
+	sqlScanLoanApplications = `SELECT ` + selectLoanApplicationColumns + `
+		FROM loan_applications
+		WHERE status = 'submitted'
+		AND timestamp >= $1 AND timestamp < $2
+		AND (timestamp, application_id) > ($3, $4)
+		ORDER BY timestamp, application_id
+		LIMIT $5`
+
+	sqlListLoanApplications = `SELECT ` + selectLoanApplicationColumns + `, COUNT(*) OVER() AS total_count
+		FROM loan_applications
+		WHERE ($1 = '' OR loan_purpose = $1)
+		AND ($4 = '' OR national_id_hash = $4)
+		LIMIT $2 OFFSET $3`
+)
+
+type Queries struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Queries {
+	return &Queries{
+		db: db,
+	}
+}
+
+func (q *Queries) InsertLoanApplication(ctx context.Context, arg LoanApplication) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlInsertLoanApplication, arg)
+	return err
+}
+
+// GetLoanApplication returns sql.ErrNoRows when applicationId does not exist.
+func (q *Queries) GetLoanApplication(ctx context.Context, applicationId string) (LoanApplication, error) {
+	var row LoanApplication
+	if err := database.Conn(ctx, q.db).GetContext(ctx, &row, sqlGetLoanApplication, applicationId); err != nil {
+		return LoanApplication{}, err
+	}
+	return row, nil
+}
+
+// GetLoanApplicationForUpdate locks the row until the surrounding
+// transaction ends. It returns sql.ErrNoRows when applicationId does not
+// exist.
+func (q *Queries) GetLoanApplicationForUpdate(ctx context.Context, applicationId string) (LoanApplication, error) {
+	var row LoanApplication
+	if err := database.Conn(ctx, q.db).GetContext(ctx, &row, sqlGetLoanApplicationForUpdate, applicationId); err != nil {
+		return LoanApplication{}, err
+	}
+	return row, nil
+}
+
+// AcceptLoanOffer stores the amount, term and decision of an accepted offer.
+func (q *Queries) AcceptLoanOffer(ctx context.Context, arg LoanApplication) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlAcceptLoanOffer, arg)
+	return err
+}
+
+// UpdateLoanApplication overwrites every column of the row, e.g. when a
+// draft is edited or submitted.
+func (q *Queries) UpdateLoanApplication(ctx context.Context, arg LoanApplication) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlUpdateLoanApplication, arg)
+	return err
+}
+
+type ListLoanApplicationsParams struct {
+	Purpose string
+	// NationalIdHash limits the list to one applicant; see
+	// nationalid.Protector.Index.
+	NationalIdHash string
+	Limit          int
+	Offset         int
+}
+
+type listLoanApplicationsRow struct {
+	LoanApplication
+	TotalCount int `db:"total_count"`
+}
+
+// ListLoanApplications returns one page of applications together with the
+// total number of rows matching the filter.
+func (q *Queries) ListLoanApplications(ctx context.Context, arg ListLoanApplicationsParams) ([]LoanApplication, int, error) {
+	var rows []listLoanApplicationsRow
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListLoanApplications, arg.Purpose, arg.Limit, arg.Offset, arg.NationalIdHash); err != nil {
+		return nil, 0, err
+	}
+
+	total := 0
+	items := make([]LoanApplication, 0, len(rows))
+	for _, v := range rows {
+		total = v.TotalCount
+		items = append(items, v.LoanApplication)
+	}
+
+	return items, total, nil
+}
+
+type ScanLoanApplicationsParams struct {
+	From  time.Time
+	To    time.Time
+	Limit int
+	// After is the last row of the previous batch; nil starts at From.
+	After *LoanApplication
+}
+
+// ScanLoanApplications returns the next batch of submitted applications
+// created in [From, To), oldest first. An empty batch ends the scan.
+func (q *Queries) ScanLoanApplications(ctx context.Context, arg ScanLoanApplicationsParams) ([]LoanApplication, error) {
+	afterTimestamp, afterId := arg.From, "00000000-0000-0000-0000-000000000000"
+	if arg.After != nil {
+		afterTimestamp, afterId = arg.After.Timestamp, arg.After.ApplicationId
+	}
+
+	rows := []LoanApplication{}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlScanLoanApplications, arg.From, arg.To, afterTimestamp, afterId, arg.Limit); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
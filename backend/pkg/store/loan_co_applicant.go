@@ -0,0 +1,137 @@
+package store
+
+import (
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/money"
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Co-applicant roles. Only co-borrowers' income counts towards eligibility.
+const (
+	RoleCoBorrower = "coBorrower"
+	RoleGuarantor  = "guarantor"
+)
+
+// LoanCoApplicant is a row of the loan_co_applicants table: a co-borrower or
+// guarantor of an application.
+type LoanCoApplicant struct {
+	ApplicationId string `db:"application_id"`
+	Role          string `db:"role"`
+	FullName      string `db:"full_name"`
+	GivenNameTh   string `db:"given_name_th"`
+	FamilyNameTh  string `db:"family_name_th"`
+	GivenNameEn   string `db:"given_name_en"`
+	FamilyNameEn  string `db:"family_name_en"`
+	FullNameKey   string `db:"full_name_key"`
+	PhoneNumber   string `db:"phone_number"`
+	PhoneE164     string `db:"phone_e164"`
+	PhoneRegion   string `db:"phone_region"`
+	PhoneType     string `db:"phone_type"`
+	Email         string `db:"email"`
+
+	DateOfBirth sql.NullTime `db:"date_of_birth"`
+
+	// The national ID is never stored in clear; see nationalid.Protector.
+	NationalIdEncrypted []byte         `db:"national_id_encrypted"`
+	NationalIdHash      sql.NullString `db:"national_id_hash"`
+
+	MonthlyIncome         int64  `db:"monthly_income"`
+	MonthlyIncomeCurrency string `db:"monthly_income_currency"`
+}
+
+func (e LoanCoApplicant) Income() money.Money {
+	return money.New(e.MonthlyIncome, e.MonthlyIncomeCurrency)
+}
+
+// CoBorrowerIncomes returns the incomes an application is assessed on
+// besides the applicant's own.
+func CoBorrowerIncomes(coApplicants []LoanCoApplicant) []money.Money {
+	incomes := []money.Money{}
+	for _, v := range coApplicants {
+		if v.Role == RoleCoBorrower {
+			incomes = append(incomes, v.Income())
+		}
+	}
+	return incomes
+}
+
+var loanCoApplicantColumns = []string{
+	"application_id",
+	"role",
+	"full_name",
+	"given_name_th",
+	"family_name_th",
+	"given_name_en",
+	"family_name_en",
+	"full_name_key",
+	"phone_number",
+	"phone_e164",
+	"phone_region",
+	"phone_type",
+	"email",
+	"date_of_birth",
+	"national_id_encrypted",
+	"national_id_hash",
+	"monthly_income",
+	"monthly_income_currency",
+}
+
+var (
+	selectLoanCoApplicantColumns = strings.Join(loanCoApplicantColumns, ", ")
+
+	sqlInsertLoanCoApplicant = `INSERT INTO loan_co_applicants (` + selectLoanCoApplicantColumns + `)
+		VALUES (:` + strings.Join(loanCoApplicantColumns, ", :") + `)`
+
+	sqlListLoanCoApplicants = `SELECT ` + selectLoanCoApplicantColumns + `
+		FROM loan_co_applicants WHERE application_id = $1 ORDER BY id`
+
+	sqlListLoanCoApplicantsIn = `SELECT ` + selectLoanCoApplicantColumns + `
+		FROM loan_co_applicants WHERE application_id = ANY($1::uuid[]) ORDER BY id`
+
+	sqlDeleteLoanCoApplicants = `DELETE FROM loan_co_applicants WHERE application_id = $1`
+)
+
+func (q *Queries) InsertLoanCoApplicants(ctx context.Context, args []LoanCoApplicant) error {
+	conn := database.Conn(ctx, q.db)
+	for _, arg := range args {
+		if _, err := sqlx.NamedExecContext(ctx, conn, sqlInsertLoanCoApplicant, arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListLoanCoApplicants returns the co-applicants in the order they were
+// given.
+func (q *Queries) ListLoanCoApplicants(ctx context.Context, applicationId string) ([]LoanCoApplicant, error) {
+	rows := []LoanCoApplicant{}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListLoanCoApplicants, applicationId); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListLoanCoApplicantsIn returns the co-applicants of every given
+// application, for listing a page of applications in one query.
+func (q *Queries) ListLoanCoApplicantsIn(ctx context.Context, applicationIds []string) ([]LoanCoApplicant, error) {
+	rows := []LoanCoApplicant{}
+	if len(applicationIds) == 0 {
+		return rows, nil
+	}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListLoanCoApplicantsIn, pq.Array(applicationIds)); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteLoanCoApplicants removes every co-applicant of the application so a
+// draft's list can be replaced as a whole.
+func (q *Queries) DeleteLoanCoApplicants(ctx context.Context, applicationId string) error {
+	_, err := database.Conn(ctx, q.db).ExecContext(ctx, sqlDeleteLoanCoApplicants, applicationId)
+	return err
+}
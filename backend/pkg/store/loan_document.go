@@ -0,0 +1,146 @@
+package store
+
+import (
+	"backend-loan-pre-approval/pkg/database"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Document scan statuses. Only clean documents can be downloaded.
+const (
+	DocumentQuarantined = "quarantined"
+	DocumentClean       = "clean"
+	DocumentInfected    = "infected"
+)
+
+// LoanDocument is a row of the loan_documents table. ContentType is the
+// type detected from the file's content, not the one the client declared.
+// ScanSignature names the malware found in an infected document.
+type LoanDocument struct {
+	DocumentId    string    `db:"document_id"`
+	ApplicationId string    `db:"application_id"`
+	DocumentType  string    `db:"document_type"`
+	FileName      string    `db:"file_name"`
+	ContentType   string    `db:"content_type"`
+	SizeBytes     int64     `db:"size_bytes"`
+	Sha256        string    `db:"sha256"`
+	StorageKey    string    `db:"storage_key"`
+	UploadedAt    time.Time `db:"uploaded_at"`
+
+	ScanStatus    string       `db:"scan_status"`
+	ScanSignature string       `db:"scan_signature"`
+	ScannedAt     sql.NullTime `db:"scanned_at"`
+}
+
+var loanDocumentColumns = []string{
+	"document_id",
+	"application_id",
+	"document_type",
+	"file_name",
+	"content_type",
+	"size_bytes",
+	"sha256",
+	"storage_key",
+	"uploaded_at",
+	"scan_status",
+	"scan_signature",
+	"scanned_at",
+}
+
+var (
+	selectLoanDocumentColumns = strings.Join(loanDocumentColumns, ", ")
+
+	sqlInsertLoanDocument = `INSERT INTO loan_documents (` + selectLoanDocumentColumns + `)
+		VALUES (:` + strings.Join(loanDocumentColumns, ", :") + `)
+		ON CONFLICT (application_id, sha256) DO NOTHING`
+
+	sqlGetLoanDocument = `SELECT ` + selectLoanDocumentColumns + `
+		FROM loan_documents WHERE application_id = $1 AND document_id = $2`
+
+	sqlGetLoanDocumentBySha256 = `SELECT ` + selectLoanDocumentColumns + `
+		FROM loan_documents WHERE application_id = $1 AND sha256 = $2`
+
+	sqlListLoanDocuments = `SELECT ` + selectLoanDocumentColumns + `
+		FROM loan_documents WHERE application_id = $1 ORDER BY uploaded_at, document_id`
+
+	sqlListQuarantinedLoanDocuments = `SELECT ` + selectLoanDocumentColumns + `
+		FROM loan_documents WHERE scan_status = '` + DocumentQuarantined + `'
+		ORDER BY uploaded_at, document_id
+		LIMIT $1`
+
+	// Only a quarantined document takes a verdict, so a document scanned
+	// twice at once keeps the first one.
+	sqlUpdateLoanDocumentScan = `UPDATE loan_documents SET
+		scan_status = :scan_status,
+		scan_signature = :scan_signature,
+		scanned_at = :scanned_at
+		WHERE document_id = :document_id AND scan_status = '` + DocumentQuarantined + `'`
+)
+
+// InsertLoanDocument reports false, and inserts nothing, when the
+// application already has a document with the same content.
+func (q *Queries) InsertLoanDocument(ctx context.Context, arg LoanDocument) (bool, error) {
+	res, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlInsertLoanDocument, arg)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// GetLoanDocument returns sql.ErrNoRows when the document does not exist or
+// belongs to another application.
+func (q *Queries) GetLoanDocument(ctx context.Context, applicationId string, documentId string) (LoanDocument, error) {
+	var row LoanDocument
+	if err := database.Conn(ctx, q.db).GetContext(ctx, &row, sqlGetLoanDocument, applicationId, documentId); err != nil {
+		return LoanDocument{}, err
+	}
+	return row, nil
+}
+
+func (q *Queries) GetLoanDocumentBySha256(ctx context.Context, applicationId string, sha256 string) (LoanDocument, error) {
+	var row LoanDocument
+	if err := database.Conn(ctx, q.db).GetContext(ctx, &row, sqlGetLoanDocumentBySha256, applicationId, sha256); err != nil {
+		return LoanDocument{}, err
+	}
+	return row, nil
+}
+
+func (q *Queries) ListLoanDocuments(ctx context.Context, applicationId string) ([]LoanDocument, error) {
+	rows := []LoanDocument{}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListLoanDocuments, applicationId); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListQuarantinedLoanDocuments returns up to limit documents awaiting a
+// scan, oldest first.
+func (q *Queries) ListQuarantinedLoanDocuments(ctx context.Context, limit int) ([]LoanDocument, error) {
+	rows := []LoanDocument{}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListQuarantinedLoanDocuments, limit); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// UpdateLoanDocumentScan records the scan verdict of a quarantined
+// document. It reports false when the document was no longer quarantined.
+func (q *Queries) UpdateLoanDocumentScan(ctx context.Context, arg LoanDocument) (bool, error) {
+	res, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlUpdateLoanDocumentScan, arg)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"backend-loan-pre-approval/pkg/database"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/types"
+)
+
+// LoanExperiment is a row of the loan_experiments table.
+type LoanExperiment struct {
+	ApplicationId      string         `db:"application_id"`
+	Experiment         string         `db:"experiment"`
+	Arm                string         `db:"arm"`
+	ChallengerEligible bool           `db:"challenger_eligible"`
+	ChallengerOutcome  string         `db:"challenger_outcome"`
+	ChallengerReason   string         `db:"challenger_reason"`
+	ChallengerDecision types.JSONText `db:"challenger_decision"`
+	CreatedAt          time.Time      `db:"created_at"`
+}
+
+var loanExperimentColumns = []string{
+	"application_id",
+	"experiment",
+	"arm",
+	"challenger_eligible",
+	"challenger_outcome",
+	"challenger_reason",
+	"challenger_decision",
+	"created_at",
+}
+
+// ExperimentArmMetrics aggregates one arm of an experiment. Champion counts
+// come from the decision stored on the application.
+type ExperimentArmMetrics struct {
+	Arm                string `db:"arm"`
+	Applications       int    `db:"applications"`
+	ChampionApproved   int    `db:"champion_approved"`
+	ChallengerApproved int    `db:"challenger_approved"`
+	Agreed             int    `db:"agreed"`
+}
+
+var (
+	sqlInsertLoanExperiment = `INSERT INTO loan_experiments (` + strings.Join(loanExperimentColumns, ", ") + `)
+		VALUES (:` + strings.Join(loanExperimentColumns, ", :") + `)`
+
+	sqlListExperimentArmMetrics = `SELECT e.arm,
+		COUNT(*) AS applications,
+		COUNT(*) FILTER (WHERE a.eligible) AS champion_approved,
+		COUNT(*) FILTER (WHERE e.challenger_eligible) AS challenger_approved,
+		COUNT(*) FILTER (WHERE a.eligible IS NOT DISTINCT FROM e.challenger_eligible) AS agreed
+		FROM loan_experiments e
+		JOIN loan_applications a ON a.application_id = e.application_id
+		WHERE e.experiment = $1
+		GROUP BY e.arm
+		ORDER BY e.arm`
+)
+
+func (q *Queries) InsertLoanExperiment(ctx context.Context, arg LoanExperiment) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlInsertLoanExperiment, arg)
+	return err
+}
+
+func (q *Queries) ListExperimentArmMetrics(ctx context.Context, experiment string) ([]ExperimentArmMetrics, error) {
+	rows := []ExperimentArmMetrics{}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListExperimentArmMetrics, experiment); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
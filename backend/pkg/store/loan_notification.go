@@ -0,0 +1,156 @@
+package store
+
+import (
+	"backend-loan-pre-approval/pkg/database"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Notification statuses. A pending notification is sent once its
+// next_attempt_at has passed; a failed one has used up its attempts.
+const (
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+)
+
+// LoanNotification is a row of the loan_notifications table. Subject is
+// empty for SMS.
+type LoanNotification struct {
+	NotificationId string       `db:"notification_id"`
+	ApplicationId  string       `db:"application_id"`
+	Event          string       `db:"event"`
+	Channel        string       `db:"channel"`
+	Locale         string       `db:"locale"`
+	Recipient      string       `db:"recipient"`
+	Subject        string       `db:"subject"`
+	Body           string       `db:"body"`
+	Status         string       `db:"status"`
+	Attempts       int          `db:"attempts"`
+	NextAttemptAt  time.Time    `db:"next_attempt_at"`
+	CreatedAt      time.Time    `db:"created_at"`
+	SentAt         sql.NullTime `db:"sent_at"`
+}
+
+var loanNotificationColumns = []string{
+	"notification_id",
+	"application_id",
+	"event",
+	"channel",
+	"locale",
+	"recipient",
+	"subject",
+	"body",
+	"status",
+	"attempts",
+	"next_attempt_at",
+	"created_at",
+	"sent_at",
+}
+
+// LoanNotificationAttempt is a row of the loan_notification_attempts table.
+// Error is empty when the attempt succeeded.
+type LoanNotificationAttempt struct {
+	AttemptId      string    `db:"attempt_id"`
+	NotificationId string    `db:"notification_id"`
+	ApplicationId  string    `db:"application_id"`
+	Channel        string    `db:"channel"`
+	Provider       string    `db:"provider"`
+	AttemptedAt    time.Time `db:"attempted_at"`
+	Succeeded      bool      `db:"succeeded"`
+	Error          string    `db:"error"`
+}
+
+var loanNotificationAttemptColumns = []string{
+	"attempt_id",
+	"notification_id",
+	"application_id",
+	"channel",
+	"provider",
+	"attempted_at",
+	"succeeded",
+	"error",
+}
+
+var (
+	selectLoanNotificationColumns        = strings.Join(loanNotificationColumns, ", ")
+	selectLoanNotificationAttemptColumns = strings.Join(loanNotificationAttemptColumns, ", ")
+
+	sqlInsertLoanNotification = `INSERT INTO loan_notifications (` + selectLoanNotificationColumns + `)
+		VALUES (:` + strings.Join(loanNotificationColumns, ", :") + `)`
+
+	sqlListLoanNotifications = `SELECT ` + selectLoanNotificationColumns + `
+		FROM loan_notifications WHERE application_id = $1 ORDER BY created_at, notification_id`
+
+	// Claiming pushes next_attempt_at to the end of the lease, so another
+	// dispatcher skips the notification while it is being sent and picks it
+	// up again if the sender dies before recording the attempt.
+	sqlClaimDueLoanNotifications = `UPDATE loan_notifications SET next_attempt_at = $2
+		WHERE notification_id IN (
+			SELECT notification_id FROM loan_notifications
+			WHERE status = '` + NotificationPending + `' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED)
+		RETURNING ` + selectLoanNotificationColumns
+
+	sqlUpdateLoanNotificationDelivery = `UPDATE loan_notifications SET
+		status = :status,
+		attempts = :attempts,
+		next_attempt_at = :next_attempt_at,
+		sent_at = :sent_at
+		WHERE notification_id = :notification_id`
+
+	sqlInsertLoanNotificationAttempt = `INSERT INTO loan_notification_attempts (` + selectLoanNotificationAttemptColumns + `)
+		VALUES (:` + strings.Join(loanNotificationAttemptColumns, ", :") + `)`
+
+	sqlListLoanNotificationAttempts = `SELECT ` + selectLoanNotificationAttemptColumns + `
+		FROM loan_notification_attempts WHERE application_id = $1 ORDER BY attempted_at, attempt_id`
+)
+
+func (q *Queries) InsertLoanNotification(ctx context.Context, arg LoanNotification) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlInsertLoanNotification, arg)
+	return err
+}
+
+func (q *Queries) ListLoanNotifications(ctx context.Context, applicationId string) ([]LoanNotification, error) {
+	rows := []LoanNotification{}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListLoanNotifications, applicationId); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ClaimDueLoanNotifications leases up to limit pending notifications due by
+// now until leaseUntil and returns them, oldest first.
+func (q *Queries) ClaimDueLoanNotifications(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]LoanNotification, error) {
+	rows := []LoanNotification{}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlClaimDueLoanNotifications, now, leaseUntil, limit); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// UpdateLoanNotificationDelivery records the outcome of a delivery attempt
+// on the notification.
+func (q *Queries) UpdateLoanNotificationDelivery(ctx context.Context, arg LoanNotification) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlUpdateLoanNotificationDelivery, arg)
+	return err
+}
+
+func (q *Queries) InsertLoanNotificationAttempt(ctx context.Context, arg LoanNotificationAttempt) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlInsertLoanNotificationAttempt, arg)
+	return err
+}
+
+func (q *Queries) ListLoanNotificationAttempts(ctx context.Context, applicationId string) ([]LoanNotificationAttempt, error) {
+	rows := []LoanNotificationAttempt{}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListLoanNotificationAttempts, applicationId); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/money"
+	"context"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// LoanObligation is a row of the loan_obligations table.
+type LoanObligation struct {
+	ApplicationId  string `db:"application_id"`
+	ObligationType string `db:"obligation_type"`
+	MonthlyPayment int64  `db:"monthly_payment"`
+	Currency       string `db:"currency"`
+}
+
+func (e LoanObligation) Payment() money.Money {
+	return money.New(e.MonthlyPayment, e.Currency)
+}
+
+var loanObligationColumns = []string{
+	"application_id",
+	"obligation_type",
+	"monthly_payment",
+	"currency",
+}
+
+var (
+	selectLoanObligationColumns = strings.Join(loanObligationColumns, ", ")
+
+	sqlInsertLoanObligation = `INSERT INTO loan_obligations (` + selectLoanObligationColumns + `)
+		VALUES (:` + strings.Join(loanObligationColumns, ", :") + `)`
+
+	sqlListLoanObligations = `SELECT ` + selectLoanObligationColumns + `
+		FROM loan_obligations WHERE application_id = $1 ORDER BY id`
+
+	sqlDeleteLoanObligations = `DELETE FROM loan_obligations WHERE application_id = $1`
+)
+
+func (q *Queries) InsertLoanObligations(ctx context.Context, args []LoanObligation) error {
+	conn := database.Conn(ctx, q.db)
+	for _, arg := range args {
+		if _, err := sqlx.NamedExecContext(ctx, conn, sqlInsertLoanObligation, arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *Queries) ListLoanObligations(ctx context.Context, applicationId string) ([]LoanObligation, error) {
+	rows := []LoanObligation{}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListLoanObligations, applicationId); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DeleteLoanObligations removes every obligation of the application so a
+// draft's list can be replaced as a whole.
+func (q *Queries) DeleteLoanObligations(ctx context.Context, applicationId string) error {
+	_, err := database.Conn(ctx, q.db).ExecContext(ctx, sqlDeleteLoanObligations, applicationId)
+	return err
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"backend-loan-pre-approval/pkg/database"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+)
+
+// LoanProduct is a row of the loan_products table.
+type LoanProduct struct {
+	Code          string         `db:"code"`
+	Name          string         `db:"name"`
+	Currency      string         `db:"currency"`
+	MinAmount     int64          `db:"min_amount"`
+	MaxAmount     int64          `db:"max_amount"`
+	MinTermMonths int            `db:"min_term_months"`
+	MaxTermMonths int            `db:"max_term_months"`
+	MinRateBps    int            `db:"min_rate_bps"`
+	MaxRateBps    int            `db:"max_rate_bps"`
+	RuleOverrides types.JSONText `db:"rule_overrides"`
+	ActiveFrom    time.Time      `db:"active_from"`
+	ActiveTo      sql.NullTime   `db:"active_to"`
+}
+
+var loanProductColumns = []string{
+	"code",
+	"name",
+	"currency",
+	"min_amount",
+	"max_amount",
+	"min_term_months",
+	"max_term_months",
+	"min_rate_bps",
+	"max_rate_bps",
+	"rule_overrides",
+	"active_from",
+	"active_to",
+}
+
+var (
+	selectLoanProductColumns = strings.Join(loanProductColumns, ", ")
+
+	sqlGetLoanProduct = `SELECT ` + selectLoanProductColumns + `
+		FROM loan_products WHERE code = $1`
+
+	sqlListLoanProducts = `SELECT ` + selectLoanProductColumns + `
+		FROM loan_products ORDER BY code`
+)
+
+// GetLoanProduct returns sql.ErrNoRows when code does not exist.
+func (q *Queries) GetLoanProduct(ctx context.Context, code string) (LoanProduct, error) {
+	var row LoanProduct
+	if err := database.Conn(ctx, q.db).GetContext(ctx, &row, sqlGetLoanProduct, code); err != nil {
+		return LoanProduct{}, err
+	}
+	return row, nil
+}
+
+func (q *Queries) ListLoanProducts(ctx context.Context) ([]LoanProduct, error) {
+	rows := []LoanProduct{}
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListLoanProducts); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
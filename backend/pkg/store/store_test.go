@@ -0,0 +1,67 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func dbTags(entity interface{}) []string {
+	tags := []string{}
+	typ := reflect.TypeOf(entity)
+	for i := 0; i < typ.NumField(); i++ {
+		tags = append(tags, typ.Field(i).Tag.Get("db"))
+	}
+	return tags
+}
+
+func TestLoanApplicationColumnsMatchEntity(t *testing.T) {
+	// Assert
+	assert.DeepEqual(t, loanApplicationColumns, dbTags(LoanApplication{}))
+}
+
+func TestLoanProductColumnsMatchEntity(t *testing.T) {
+	// Assert
+	assert.DeepEqual(t, loanProductColumns, dbTags(LoanProduct{}))
+}
+
+func TestLoanObligationColumnsMatchEntity(t *testing.T) {
+	// Assert
+	assert.DeepEqual(t, loanObligationColumns, dbTags(LoanObligation{}))
+}
+
+func TestCreditReportColumnsMatchEntity(t *testing.T) {
+	// Assert
+	assert.DeepEqual(t, creditReportColumns, dbTags(CreditReport{}))
+}
+
+func TestUnderwritingItemColumnsMatchEntity(t *testing.T) {
+	// Assert
+	assert.DeepEqual(t, underwritingItemColumns, dbTags(UnderwritingItem{}))
+}
+
+func TestLoanExperimentColumnsMatchEntity(t *testing.T) {
+	// Assert
+	assert.DeepEqual(t, loanExperimentColumns, dbTags(LoanExperiment{}))
+}
+
+func TestLoanCoApplicantColumnsMatchEntity(t *testing.T) {
+	// Assert
+	assert.DeepEqual(t, loanCoApplicantColumns, dbTags(LoanCoApplicant{}))
+}
+
+func TestLoanDocumentColumnsMatchEntity(t *testing.T) {
+	// Assert
+	assert.DeepEqual(t, loanDocumentColumns, dbTags(LoanDocument{}))
+}
+
+func TestLoanNotificationColumnsMatchEntity(t *testing.T) {
+	// Assert
+	assert.DeepEqual(t, loanNotificationColumns, dbTags(LoanNotification{}))
+}
+
+func TestLoanNotificationAttemptColumnsMatchEntity(t *testing.T) {
+	// Assert
+	assert.DeepEqual(t, loanNotificationAttemptColumns, dbTags(LoanNotificationAttempt{}))
+}
@@ -0,0 +1,181 @@
+package store
+
+import (
+	"backend-loan-pre-approval/pkg/database"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Underwriting queue statuses.
+const (
+	UnderwritingPending = "pending"
+	UnderwritingClaimed = "claimed"
+	UnderwritingDecided = "decided"
+)
+
+// UnderwritingItem is a row of the underwriting_queue table.
+type UnderwritingItem struct {
+	ApplicationId  string         `db:"application_id"`
+	Status         string         `db:"status"`
+	ClaimedBy      sql.NullString `db:"claimed_by"`
+	ClaimExpiresAt sql.NullTime   `db:"claim_expires_at"`
+	DecidedBy      sql.NullString `db:"decided_by"`
+	DecidedOutcome sql.NullString `db:"decided_outcome"`
+	Notes          sql.NullString `db:"notes"`
+	CreatedAt      time.Time      `db:"created_at"`
+	DecidedAt      sql.NullTime   `db:"decided_at"`
+}
+
+var underwritingItemColumns = []string{
+	"application_id",
+	"status",
+	"claimed_by",
+	"claim_expires_at",
+	"decided_by",
+	"decided_outcome",
+	"notes",
+	"created_at",
+	"decided_at",
+}
+
+var (
+	selectUnderwritingItemColumns = strings.Join(underwritingItemColumns, ", ")
+
+	sqlInsertUnderwritingItem = `INSERT INTO underwriting_queue (` + selectUnderwritingItemColumns + `)
+		VALUES (:` + strings.Join(underwritingItemColumns, ", :") + `)`
+
+	sqlGetUnderwritingItem = `SELECT ` + selectUnderwritingItemColumns + `
+		FROM underwriting_queue WHERE application_id = $1`
+
+	sqlGetUnderwritingItemForUpdate = `SELECT ` + selectUnderwritingItemColumns + `
+		FROM underwriting_queue WHERE application_id = $1 FOR UPDATE`
+
+	// A pending item, an item whose lease has run out, or one the same
+	// officer already holds can be claimed.
+	sqlClaimUnderwritingItem = `UPDATE underwriting_queue SET
+		status = '` + UnderwritingClaimed + `',
+		claimed_by = $2,
+		claim_expires_at = $4
+		WHERE application_id = $1
+		AND (status = '` + UnderwritingPending + `'
+			OR (status = '` + UnderwritingClaimed + `' AND (claim_expires_at <= $3 OR claimed_by = $2)))
+		RETURNING ` + selectUnderwritingItemColumns
+
+	sqlDecideUnderwritingItem = `UPDATE underwriting_queue SET
+		status = :status,
+		decided_by = :decided_by,
+		decided_outcome = :decided_outcome,
+		notes = :notes,
+		decided_at = :decided_at
+		WHERE application_id = :application_id`
+
+	sqlReleaseExpiredClaims = `UPDATE underwriting_queue SET
+		status = '` + UnderwritingPending + `',
+		claimed_by = NULL,
+		claim_expires_at = NULL
+		WHERE status = '` + UnderwritingClaimed + `' AND claim_expires_at <= $1`
+
+	sqlListUnderwritingItems = `SELECT ` + selectUnderwritingItemColumns + `, COUNT(*) OVER() AS total_count
+		FROM underwriting_queue
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at
+		LIMIT $2 OFFSET $3`
+
+	sqlUpdateLoanApplicationOutcome = `UPDATE loan_applications SET
+		eligible = :eligible,
+		reason = :reason,
+		outcome = :outcome
+		WHERE application_id = :application_id`
+)
+
+func (q *Queries) InsertUnderwritingItem(ctx context.Context, arg UnderwritingItem) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlInsertUnderwritingItem, arg)
+	return err
+}
+
+// GetUnderwritingItem returns sql.ErrNoRows when the application is not
+// queued.
+func (q *Queries) GetUnderwritingItem(ctx context.Context, applicationId string) (UnderwritingItem, error) {
+	var row UnderwritingItem
+	if err := database.Conn(ctx, q.db).GetContext(ctx, &row, sqlGetUnderwritingItem, applicationId); err != nil {
+		return UnderwritingItem{}, err
+	}
+	return row, nil
+}
+
+// GetUnderwritingItemForUpdate locks the item until the surrounding
+// transaction ends. It returns sql.ErrNoRows when the application is not
+// queued.
+func (q *Queries) GetUnderwritingItemForUpdate(ctx context.Context, applicationId string) (UnderwritingItem, error) {
+	var row UnderwritingItem
+	if err := database.Conn(ctx, q.db).GetContext(ctx, &row, sqlGetUnderwritingItemForUpdate, applicationId); err != nil {
+		return UnderwritingItem{}, err
+	}
+	return row, nil
+}
+
+// ClaimUnderwritingItem leases the item to officer until expiresAt. It
+// returns sql.ErrNoRows when the item does not exist or is held by someone
+// else.
+func (q *Queries) ClaimUnderwritingItem(ctx context.Context, applicationId string, officer string, now time.Time, expiresAt time.Time) (UnderwritingItem, error) {
+	var row UnderwritingItem
+	if err := database.Conn(ctx, q.db).GetContext(ctx, &row, sqlClaimUnderwritingItem, applicationId, officer, now, expiresAt); err != nil {
+		return UnderwritingItem{}, err
+	}
+	return row, nil
+}
+
+func (q *Queries) DecideUnderwritingItem(ctx context.Context, arg UnderwritingItem) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlDecideUnderwritingItem, arg)
+	return err
+}
+
+// ReleaseExpiredClaims puts items whose lease ended by now back to pending
+// and returns how many were released.
+func (q *Queries) ReleaseExpiredClaims(ctx context.Context, now time.Time) (int64, error) {
+	res, err := database.Conn(ctx, q.db).ExecContext(ctx, sqlReleaseExpiredClaims, now)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+type ListUnderwritingItemsParams struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+type listUnderwritingItemsRow struct {
+	UnderwritingItem
+	TotalCount int `db:"total_count"`
+}
+
+// ListUnderwritingItems returns one page of the queue, oldest first, together
+// with the total number of items matching the filter.
+func (q *Queries) ListUnderwritingItems(ctx context.Context, arg ListUnderwritingItemsParams) ([]UnderwritingItem, int, error) {
+	var rows []listUnderwritingItemsRow
+	if err := database.Conn(ctx, q.db).SelectContext(ctx, &rows, sqlListUnderwritingItems, arg.Status, arg.Limit, arg.Offset); err != nil {
+		return nil, 0, err
+	}
+
+	total := 0
+	items := make([]UnderwritingItem, 0, len(rows))
+	for _, v := range rows {
+		total = v.TotalCount
+		items = append(items, v.UnderwritingItem)
+	}
+
+	return items, total, nil
+}
+
+// UpdateLoanApplicationOutcome records a manual decision on the application.
+// The automated decision column is left as it was.
+func (q *Queries) UpdateLoanApplicationOutcome(ctx context.Context, arg LoanApplication) error {
+	_, err := sqlx.NamedExecContext(ctx, database.Conn(ctx, q.db), sqlUpdateLoanApplicationOutcome, arg)
+	return err
+}
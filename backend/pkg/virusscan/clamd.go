@@ -0,0 +1,119 @@
+package virusscan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// chunkSize is the size of the INSTREAM chunks sent to clamd; it must stay
+// below clamd's StreamMaxLength.
+const chunkSize = 64 << 10
+
+// Clamd scans with a ClamAV daemon over its INSTREAM command, so the daemon
+// needs no access to the files.
+type Clamd struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamd connects to address, either "unix:///var/run/clamav/clamd.ctl"
+// or "tcp://clamav:3310". Each scan must finish within timeout.
+func NewClamd(address string, timeout time.Duration) (*Clamd, error) {
+	c := &Clamd{timeout: timeout}
+	if path, ok := strings.CutPrefix(address, "unix://"); ok {
+		c.network, c.address = "unix", path
+	} else if hostPort, ok := strings.CutPrefix(address, "tcp://"); ok {
+		c.network, c.address = "tcp", hostPort
+	}
+	if c.network == "" || c.address == "" {
+		return nil, fmt.Errorf("invalid clamd address %q", address)
+	}
+	return c, nil
+}
+
+func (c *Clamd) Name() string {
+	return "clamd"
+}
+
+func (c *Clamd) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return Result{}, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	// clamd answers and closes the connection early when the stream is
+	// over its size limit, so a failed write still has a reply to read.
+	writeErr := stream(conn, r)
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(errors.Is(err, io.EOF) && reply != "") {
+		if writeErr != nil {
+			return Result{}, writeErr
+		}
+		return Result{}, err
+	}
+	return parseReply(reply)
+}
+
+// stream sends r as INSTREAM chunks, each preceded by its length, and ends
+// with an empty chunk.
+func stream(w io.Writer, r io.Reader) error {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4+chunkSize)
+	for {
+		n, err := r.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := w.Write(buf[:4+n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+// parseReply reads "stream: OK", "stream: Eicar-Signature FOUND" or
+// "... ERROR".
+func parseReply(reply string) (Result, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	verdict := strings.TrimPrefix(reply, "stream: ")
+
+	switch {
+	case verdict == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd: %s", reply)
+	}
+}
@@ -0,0 +1,33 @@
+package virusscan
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+// EICAR is the standard antivirus test file, which every scanner reports as
+// infected.
+const EICAR = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+const eicarSignature = "Eicar-Test-Signature"
+
+// Fake reports content containing the EICAR test string as infected and
+// everything else as clean. It finds no real malware and is meant for tests
+// and local development.
+type Fake struct{}
+
+func (Fake) Name() string {
+	return "fake"
+}
+
+func (Fake) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return Result{}, err
+	}
+	if bytes.Contains(content, []byte(EICAR)) {
+		return Result{Infected: true, Signature: eicarSignature}, nil
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,22 @@
+// Package virusscan checks uploaded files for malware before anyone opens
+// them.
+package virusscan
+
+import (
+	"context"
+	"io"
+)
+
+// Result is a scanner's verdict. Signature names the malware found and is
+// empty for clean content.
+type Result struct {
+	Infected  bool
+	Signature string
+}
+
+// Scanner scans one file. An error means no verdict was reached, e.g. the
+// scanner could not be reached, and the file should be scanned again later.
+type Scanner interface {
+	Name() string
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
@@ -0,0 +1,110 @@
+package virusscan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+// serveClamd is a stand-in for clamd answering INSTREAM on a Unix socket. It
+// finds only the EICAR test string and, like clamd, gives up on streams over
+// maxLength.
+func serveClamd(t *testing.T, maxLength int) string {
+	socket := filepath.Join(t.TempDir(), "clamd.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.NilError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				if command, err := r.ReadString(0); err != nil || command != "zINSTREAM\x00" {
+					io.WriteString(conn, "UNKNOWN COMMAND\x00")
+					return
+				}
+
+				var content bytes.Buffer
+				for {
+					var size uint32
+					if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					if content.Len()+int(size) > maxLength {
+						io.WriteString(conn, "INSTREAM size limit exceeded. ERROR\x00")
+						return
+					}
+					if _, err := io.CopyN(&content, r, int64(size)); err != nil {
+						return
+					}
+				}
+
+				if bytes.Contains(content.Bytes(), []byte(EICAR)) {
+					io.WriteString(conn, "stream: Eicar-Test-Signature FOUND\x00")
+					return
+				}
+				io.WriteString(conn, "stream: OK\x00")
+			}()
+		}
+	}()
+
+	return "unix://" + socket
+}
+
+func TestClamd(t *testing.T) {
+	ctx := context.Background()
+	scanner, err := NewClamd(serveClamd(t, 1<<20), 5*time.Second)
+	assert.NilError(t, err)
+
+	// Spread over several chunks, with the test string across a boundary.
+	infectedContent := append(bytes.Repeat([]byte("A"), chunkSize-10), []byte(EICAR)...)
+
+	clean, cleanErr := scanner.Scan(ctx, strings.NewReader("%PDF-1.7\n%%EOF\n"))
+	infected, infectedErr := scanner.Scan(ctx, bytes.NewReader(infectedContent))
+	_, tooLargeErr := scanner.Scan(ctx, bytes.NewReader(make([]byte, 2<<20)))
+
+	// Assert
+	assert.NilError(t, cleanErr)
+	assert.Equal(t, Result{}, clean)
+	assert.NilError(t, infectedErr)
+	assert.Equal(t, Result{Infected: true, Signature: "Eicar-Test-Signature"}, infected)
+	assert.ErrorContains(t, tooLargeErr, "size limit exceeded")
+}
+
+func TestClamdUnavailable(t *testing.T) {
+	scanner, err := NewClamd("unix://"+filepath.Join(t.TempDir(), "missing.sock"), time.Second)
+	assert.NilError(t, err)
+
+	_, scanErr := scanner.Scan(context.Background(), strings.NewReader("x"))
+	_, addressErr := NewClamd("localhost:3310", time.Second)
+
+	// Assert
+	assert.Assert(t, scanErr != nil)
+	assert.ErrorContains(t, addressErr, "invalid clamd address")
+}
+
+func TestFake(t *testing.T) {
+	clean, _ := Fake{}.Scan(context.Background(), strings.NewReader("%PDF-1.7"))
+	infected, _ := Fake{}.Scan(context.Background(), strings.NewReader(EICAR))
+
+	// Assert
+	assert.Equal(t, false, clean.Infected)
+	assert.Equal(t, true, infected.Infected)
+}
@@ -1,25 +1,289 @@
 package routes
 
 import (
+	"backend-loan-pre-approval/app/documents"
+	"backend-loan-pre-approval/app/eligibility"
+	"backend-loan-pre-approval/app/experiments"
 	"backend-loan-pre-approval/app/loancreate"
 	"backend-loan-pre-approval/app/loaninquiry"
+	"backend-loan-pre-approval/app/loanoffer"
+	"backend-loan-pre-approval/app/notifications"
+	"backend-loan-pre-approval/app/products"
+	"backend-loan-pre-approval/app/quotes"
+	"backend-loan-pre-approval/app/underwriting"
+	"backend-loan-pre-approval/configs"
+	"backend-loan-pre-approval/pkg/blobstore"
+	"backend-loan-pre-approval/pkg/creditbureau"
+	"backend-loan-pre-approval/pkg/database"
+	"backend-loan-pre-approval/pkg/emailaddr"
+	"backend-loan-pre-approval/pkg/fx"
+	"backend-loan-pre-approval/pkg/nationalid"
+	"backend-loan-pre-approval/pkg/notify"
+	"backend-loan-pre-approval/pkg/officerauth"
+	"backend-loan-pre-approval/pkg/phone"
+	"backend-loan-pre-approval/pkg/scorecard"
+	"backend-loan-pre-approval/pkg/virusscan"
+	"context"
+	"fmt"
+	"log"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 )
 
-func SetupRoutes(r *gin.Engine, db *sqlx.DB) {
+func SetupRoutes(r *gin.Engine, db *sqlx.DB, appconf configs.AppConfig) error {
+
+	txManager := database.NewTxManager(db)
+
+	if appconf.Phone.DefaultRegion != "" {
+		if err := phone.SetDefaultRegion(appconf.Phone.DefaultRegion); err != nil {
+			return err
+		}
+	}
+	if appconf.Email.DisposableDomainsFile != "" {
+		blocklist, err := emailaddr.LoadBlocklist(appconf.Email.DisposableDomainsFile)
+		if err != nil {
+			return err
+		}
+		emailaddr.SetBlocklist(blocklist)
+	}
+
+	rates, err := fx.LoadFile(appconf.FX.RatesFile, appconf.FX.BaseCurrency)
+	if err != nil {
+		return err
+	}
+	var card *scorecard.Scorecard
+	if appconf.Scorecard.File != "" {
+		if card, err = scorecard.LoadFile(appconf.Scorecard.File); err != nil {
+			return err
+		}
+	}
+	rules := eligibility.DefaultRuleset()
+	rules.Employment = appconf.Eligibility.Employment
+	eligibilityEngine := eligibility.NewEngine(rules, rates, card)
+
+	var experiment *eligibility.Experiment
+	if appconf.Experiment.ChallengerRuleset != "" {
+		challengerRules, err := eligibility.LoadRuleset(appconf.Experiment.ChallengerRuleset, rules)
+		if err != nil {
+			return err
+		}
+		challenger := eligibility.NewEngine(challengerRules, rates, card)
+		experiment, err = eligibility.NewExperiment(challenger, appconf.Experiment.ChallengerPercent)
+		if err != nil {
+			return err
+		}
+	}
+
+	bureau, err := newCreditBureau(appconf)
+	if err != nil {
+		return err
+	}
+
+	nationalIds, err := nationalid.NewProtector(appconf.NationalId.EncryptionKey, appconf.NationalId.IndexKey, appconf.NationalId.Required, appconf.NationalId.AllowDevKeys)
+	if err != nil {
+		return err
+	}
+
+	blobs, err := newBlobStore(appconf)
+	if err != nil {
+		return err
+	}
+	scanner, err := newScanner(appconf)
+	if err != nil {
+		return err
+	}
+	maxDocumentSize := appconf.Documents.MaxSizeBytes
+	if maxDocumentSize <= 0 {
+		maxDocumentSize = documents.DefaultMaxSizeBytes
+	}
+
+	emailSender, err := newEmailSender(appconf)
+	if err != nil {
+		return err
+	}
+	smsSender, err := newSMSSender(appconf)
+	if err != nil {
+		return err
+	}
+
+	notificationsRepo := notifications.NewRepository(db)
+	notificationsSrv := notifications.NewService(notificationsRepo, txManager, emailSender, smsSender, appconf.Notifications.DefaultLocale)
+	notificationsHandler := notifications.NewHandler(notificationsSrv)
+	notifications.StartDispatcher(context.Background(), notificationsSrv, appconf.Notifications.Interval)
+
+	productsRepo := products.NewRepository(db)
+	productsSrv := products.NewService(productsRepo)
+	productsHandler := products.NewHandler(productsSrv)
+
+	quotesSrv := quotes.NewService(productsSrv)
+	quotesHandler := quotes.NewHandler(quotesSrv)
 
 	loanCreateRepo := loancreate.NewRepository(db)
-	loanCreatesrv := loancreate.NewService(loanCreateRepo)
+	loanCreatesrv := loancreate.NewService(loanCreateRepo, txManager, eligibilityEngine, productsSrv, bureau, experiment, nationalIds, appconf.NationalId.Required, notificationsSrv)
 	loanCreatehandler := loancreate.NewHandler(loanCreatesrv)
 
 	loanInquiryRepo := loaninquiry.NewRepository(db)
-	loanInquirySrv := loaninquiry.NewService(loanInquiryRepo)
+	loanInquirySrv := loaninquiry.NewService(loanInquiryRepo, eligibilityEngine, nationalIds)
 	loanInquiryHandler := loaninquiry.NewHandler(loanInquirySrv)
 
+	loanOfferRepo := loanoffer.NewRepository(db)
+	loanOfferSrv := loanoffer.NewService(loanOfferRepo, txManager, eligibilityEngine, productsSrv, notificationsSrv)
+	loanOfferHandler := loanoffer.NewHandler(loanOfferSrv)
+
+	officerTokens, err := officerauth.ParseTokens(appconf.Underwriting.OfficerTokens)
+	if err != nil {
+		return err
+	}
+	if len(officerTokens) == 0 {
+		log.Println("no officer tokens configured; underwriting and document routes will reject every request")
+	}
+
+	underwritingRepo := underwriting.NewRepository(db)
+	underwritingSrv := underwriting.NewService(underwritingRepo, txManager, appconf.Underwriting.ClaimLease, notificationsSrv)
+	underwritingHandler := underwriting.NewHandler(underwritingSrv)
+	underwriting.StartReleaser(context.Background(), underwritingSrv, appconf.Underwriting.ReleaseInterval)
+
+	documentsRepo := documents.NewRepository(db)
+	documentsSrv := documents.NewService(documentsRepo, blobs, scanner, maxDocumentSize)
+	documentsHandler := documents.NewHandler(documentsSrv, maxDocumentSize)
+	documents.StartScanner(context.Background(), documentsSrv, appconf.Documents.Scanner.Interval)
+
+	experimentsRepo := experiments.NewRepository(db)
+	experimentsSrv := experiments.NewService(experimentsRepo)
+	experimentsHandler := experiments.NewHandler(experimentsSrv)
+
 	r.POST("/api/v1/loans", loanCreatehandler.LoansCreate)
+	r.POST("/api/v1/eligibility/simulate", loanCreatehandler.EligibilitySimulate)
 	r.GET("/api/v1/loans/:applicationId", loanInquiryHandler.GetLoanApplicationWithAppId)
+	r.PATCH("/api/v1/loans/:applicationId", loanCreatehandler.LoansUpdateDraft)
+	r.POST("/api/v1/loans/:applicationId/submit", loanCreatehandler.LoansSubmitDraft)
+	r.POST("/api/v1/loans/:applicationId/offer/accept", loanOfferHandler.AcceptOffer)
+	r.POST("/api/v1/loans/:applicationId/documents", documentsHandler.UploadDocument)
+	r.GET("/api/v1/loans/:applicationId/notifications", notificationsHandler.GetNotifications)
 	r.GET("/api/v1/loans", loanInquiryHandler.GetAllLoanApplication)
+	r.GET("/api/v1/products", productsHandler.GetProducts)
+	r.POST("/api/v1/quotes", quotesHandler.CreateQuote)
+
+	officer := r.Group("/api/v1", officerauth.Required(officerTokens))
+	officer.GET("/loans/:applicationId/documents", documentsHandler.GetDocuments)
+	officer.GET("/loans/:applicationId/documents/:documentId", documentsHandler.DownloadDocument)
+	officer.GET("/underwriting/queue", underwritingHandler.GetQueue)
+	officer.POST("/underwriting/queue/:applicationId/claim", underwritingHandler.ClaimItem)
+	officer.POST("/underwriting/queue/:applicationId/decision", underwritingHandler.DecideItem)
+	officer.GET("/experiments/:experiment/metrics", experimentsHandler.GetMetrics)
+
+	return nil
+}
+
+// newCreditBureau returns nil when no provider is configured, which turns the
+// credit score rule off.
+func newCreditBureau(appconf configs.AppConfig) (creditbureau.CreditBureau, error) {
+	conf := appconf.CreditBureau
+
+	var provider creditbureau.CreditBureau
+	switch conf.Provider {
+	case "":
+		return nil, nil
+	case "file":
+		fileBureau, err := creditbureau.LoadFile(conf.File)
+		if err != nil {
+			return nil, err
+		}
+		provider = fileBureau
+	case "http":
+		provider = creditbureau.NewHTTPBureau(conf.URL, nil)
+	default:
+		return nil, fmt.Errorf("unknown credit bureau provider %q", conf.Provider)
+	}
+
+	return creditbureau.NewClient(provider, creditbureau.Options{
+		Timeout:          conf.Timeout,
+		MaxRetries:       conf.MaxRetries,
+		RetryBackoff:     conf.RetryBackoff,
+		FailureThreshold: conf.FailureThreshold,
+		OpenDuration:     conf.OpenDuration,
+	}), nil
+}
+
+func newBlobStore(appconf configs.AppConfig) (blobstore.BlobStore, error) {
+	conf := appconf.Documents
+
+	switch conf.Storage {
+	case "", "file":
+		return blobstore.NewFileStore(conf.Dir)
+	case "s3":
+		return blobstore.NewS3Store(blobstore.S3Options{
+			Endpoint:  conf.S3.Endpoint,
+			Bucket:    conf.S3.Bucket,
+			Region:    conf.S3.Region,
+			AccessKey: conf.S3.AccessKey,
+			SecretKey: conf.S3.SecretKey,
+			PathStyle: conf.S3.PathStyle,
+		}, nil)
+	default:
+		return nil, fmt.Errorf("unknown document storage %q", conf.Storage)
+	}
+}
+
+// newScanner returns nil when no scanner is configured, which leaves uploaded
+// documents quarantined.
+func newScanner(appconf configs.AppConfig) (virusscan.Scanner, error) {
+	conf := appconf.Documents.Scanner
+
+	switch conf.Provider {
+	case "":
+		log.Println("no document scanner configured; uploaded documents stay quarantined")
+		return nil, nil
+	case "clamd":
+		return virusscan.NewClamd(conf.Address, conf.Timeout)
+	case "fake":
+		log.Println("documents are scanned by the fake scanner, which only detects the EICAR test file")
+		return virusscan.Fake{}, nil
+	default:
+		return nil, fmt.Errorf("unknown document scanner %q", conf.Provider)
+	}
+}
+
+// newEmailSender returns nil when no provider or SMTP address is configured,
+// in which case applicants are not emailed.
+func newEmailSender(appconf configs.AppConfig) (notify.EmailSender, error) {
+	conf := appconf.Notifications.Email
+
+	switch conf.Provider {
+	case "":
+		return nil, nil
+	case "smtp":
+		if conf.Address == "" {
+			log.Println("no SMTP address configured; applicants are not sent email")
+			return nil, nil
+		}
+		return notify.NewSMTPSender(notify.SMTPOptions{
+			Address:  conf.Address,
+			Username: conf.Username,
+			Password: conf.Password,
+			From:     conf.From,
+			Timeout:  conf.Timeout,
+		})
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", conf.Provider)
+	}
+}
+
+// newSMSSender returns nil when no provider is configured, in which case
+// applicants are not sent SMS.
+func newSMSSender(appconf configs.AppConfig) (notify.SMSSender, error) {
+	conf := appconf.Notifications.SMS
 
+	switch conf.Provider {
+	case "":
+		return nil, nil
+	case "http":
+		return notify.NewHTTPSMS(conf.URL, conf.APIKey, nil), nil
+	case "log":
+		log.Println("SMS are written to the log instead of being sent")
+		return notify.LogSMS{}, nil
+	default:
+		return nil, fmt.Errorf("unknown SMS provider %q", conf.Provider)
+	}
 }
@@ -79,15 +79,14 @@ const App = memo(() => {
           disabled={isSubmitting}
         />
         <TextInput
-          id="age"
-          label="Age*"
-          type="number"
-          placeholder="Age"
-          value={formData.age}
-          onChange={(val) => handleInputChange("age", val)}
-          error={errors.age}
+          id="dateOfBirth"
+          label="Date of Birth*"
+          type="date"
+          placeholder="Date of Birth"
+          value={formData.dateOfBirth}
+          onChange={(val) => handleInputChange("dateOfBirth", val)}
+          error={errors.dateOfBirth}
           disabled={isSubmitting}
-          min={1}
         />
         <TextInput
           id="email"
@@ -34,7 +34,7 @@ describe("useLoanForm Hook", () => {
         monthlyIncome: "",
         loanAmount: "",
         loanPurpose: "",
-        age: "",
+        dateOfBirth: "",
         email: "",
       })
       expect(result.current.errors).toEqual({})
@@ -68,7 +68,7 @@ describe("useLoanForm Hook", () => {
       act(() => {
         result.current.handleInputChange("fullName", "John Doe")
         result.current.handleInputChange("email", "john@example.com")
-        result.current.handleInputChange("age", "30")
+        result.current.handleInputChange("dateOfBirth", "1995-06-15")
       })
 
       expect(result.current.formData).toEqual({
@@ -77,7 +77,7 @@ describe("useLoanForm Hook", () => {
         monthlyIncome: "",
         loanAmount: "",
         loanPurpose: "",
-        age: "30",
+        dateOfBirth: "1995-06-15",
         email: "john@example.com",
       })
     })
@@ -396,45 +396,63 @@ describe("useLoanForm Hook", () => {
       })
     })
 
-    describe("Age Validation", () => {
-      test("validates empty age", async () => {
+    describe("Date of Birth Validation", () => {
+      test("validates empty date of birth", async () => {
         const { result } = renderHook(() => useLoanForm())
 
         await act(async () => {
           await result.current.handleSubmit({ preventDefault: jest.fn() })
         })
 
-        expect(result.current.errors.age).toBe("Age is required")
+        expect(result.current.errors.dateOfBirth).toBe(
+          "Date of birth is required"
+        )
+      })
+
+      test("validates invalid date of birth", async () => {
+        const { result } = renderHook(() => useLoanForm())
+
+        act(() => {
+          result.current.handleInputChange("dateOfBirth", "1995-13-40")
+        })
+
+        await act(async () => {
+          await result.current.handleSubmit({ preventDefault: jest.fn() })
+        })
+
+        expect(result.current.errors.dateOfBirth).toBe(
+          "Please enter a valid date of birth"
+        )
       })
 
-      test("validates invalid age", async () => {
+      test("validates date of birth in the future", async () => {
         const { result } = renderHook(() => useLoanForm())
 
         act(() => {
-          result.current.handleInputChange("age", "0")
+          result.current.handleInputChange("dateOfBirth", "2999-01-01")
         })
 
         await act(async () => {
           await result.current.handleSubmit({ preventDefault: jest.fn() })
         })
 
-        expect(result.current.errors.age).toBe(
-          "Age must be a number greater than 0"
+        expect(result.current.errors.dateOfBirth).toBe(
+          "Date of birth must not be in the future"
         )
       })
 
-      test("accepts valid age", async () => {
+      test("accepts valid date of birth", async () => {
         const { result } = renderHook(() => useLoanForm())
 
         act(() => {
-          result.current.handleInputChange("age", "30")
+          result.current.handleInputChange("dateOfBirth", "1995-06-15")
         })
 
         await act(async () => {
           await result.current.handleSubmit({ preventDefault: jest.fn() })
         })
 
-        expect(result.current.errors.age).toBeUndefined()
+        expect(result.current.errors.dateOfBirth).toBeUndefined()
       })
     })
 
@@ -490,7 +508,7 @@ describe("useLoanForm Hook", () => {
       monthlyIncome: "50000",
       loanAmount: "240000",
       loanPurpose: "education",
-      age: "30",
+      dateOfBirth: "1995-06-15",
       email: "john@example.com",
     }
 
@@ -545,7 +563,7 @@ describe("useLoanForm Hook", () => {
             monthlyIncome: 50000,
             loanAmount: 240000,
             loanPurpose: "education",
-            age: 30,
+            dateOfBirth: "1995-06-15",
             email: "john@example.com",
           }),
         }
@@ -562,7 +580,7 @@ describe("useLoanForm Hook", () => {
         monthlyIncome: "",
         loanAmount: "",
         loanPurpose: "",
-        age: "",
+        dateOfBirth: "",
         email: "",
       })
     })
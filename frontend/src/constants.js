@@ -4,7 +4,7 @@ export const INITIAL_FORM_DATA = {
   monthlyIncome: "",
   loanAmount: "",
   loanPurpose: "",
-  age: "",
+  dateOfBirth: "",
   email: "",
 }
 
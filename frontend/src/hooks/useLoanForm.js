@@ -55,10 +55,12 @@ export function useLoanForm() {
     return ""
   }
 
-  const validateAge = (age) => {
-    if (!age.trim()) return "Age is required"
-    const num = Number.parseInt(age, 10)
-    if (isNaN(num) || num <= 0) return "Age must be a number greater than 0"
+  const validateDateOfBirth = (dateOfBirth) => {
+    if (!dateOfBirth.trim()) return "Date of birth is required"
+    const date = new Date(`${dateOfBirth}T00:00:00Z`)
+    if (!/^\d{4}-\d{2}-\d{2}$/.test(dateOfBirth) || isNaN(date.getTime()))
+      return "Please enter a valid date of birth"
+    if (date > new Date()) return "Date of birth must not be in the future"
     return ""
   }
 
@@ -85,7 +87,7 @@ export function useLoanForm() {
       monthlyIncome: validateMonthlyIncome(formData.monthlyIncome),
       loanAmount: validateLoanAmount(formData.loanAmount),
       loanPurpose: validateLoanPurpose(formData.loanPurpose),
-      age: validateAge(formData.age),
+      dateOfBirth: validateDateOfBirth(formData.dateOfBirth),
       phoneNumber: validatePhoneNumber(formData.phoneNumber),
       email: validateEmail(formData.email),
     }
@@ -116,7 +118,6 @@ export function useLoanForm() {
           ...formData,
           monthlyIncome: Number.parseFloat(formData.monthlyIncome),
           loanAmount: Number.parseFloat(formData.loanAmount),
-          age: Number.parseInt(formData.age, 10),
         }
 
         const response = await fetch(`${apiUrl}/api/v1/loans`, {
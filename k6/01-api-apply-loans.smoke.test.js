@@ -12,6 +12,15 @@ export let options = {
 
 const BASE_URL = __ENV.BASE_URL || 'http://localhost:30090';
 
+// dateOfBirth is the date of birth of someone who turned age half a year ago,
+// so the applicant's age does not drift as the script gets older.
+function dateOfBirth(age) {
+  const d = new Date();
+  d.setUTCFullYear(d.getUTCFullYear() - age);
+  d.setUTCMonth(d.getUTCMonth() - 6);
+  return d.toISOString().slice(0, 10);
+}
+
 export default function () {
   const suiteId = uuidv4();
   // Test cases for different eligibility scenarios
@@ -23,7 +32,7 @@ export default function () {
         monthlyIncome: 50000, // Sufficient income
         loanAmount: 240000, // 2 * 50000 * 12 = 1200000
         loanPurpose: 'education',
-        age: 30, // Within age range
+        dateOfBirth: dateOfBirth(30), // Within age range
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -39,7 +48,7 @@ export default function () {
         monthlyIncome: 9999, // Insufficient income
         loanAmount: 200001, // Monthly income < 2 * (120000/12) and loan amount <= 10 * monthlyIncome
         loanPurpose: 'education',
-        age: 30, // Within age range
+        dateOfBirth: dateOfBirth(30), // Within age range
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -55,7 +64,7 @@ export default function () {
         monthlyIncome: 50000, // Sufficient income
         loanAmount: 240000, // Monthly income >= 2 * (240000/12)
         loanPurpose: 'education',
-        age: 19, // Age < 20
+        dateOfBirth: dateOfBirth(19), // Age < 20
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -70,7 +79,7 @@ export default function () {
         monthlyIncome: 50000, // Sufficient income
         loanAmount: 240000, // Monthly income >= 2 * (240000/12)
         loanPurpose: 'education',
-        age: 61, // Age > 60
+        dateOfBirth: dateOfBirth(61), // Age > 60
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -86,7 +95,7 @@ export default function () {
         monthlyIncome: 500000,
         loanAmount: 1000000,
         loanPurpose: 'business',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -11,6 +11,15 @@ export let options = {
 
 const BASE_URL = __ENV.BASE_URL || 'http://localhost:30090';
 
+// dateOfBirth is the date of birth of someone who turned age half a year ago,
+// so the applicant's age does not drift as the script gets older.
+function dateOfBirth(age) {
+  const d = new Date();
+  d.setUTCFullYear(d.getUTCFullYear() - age);
+  d.setUTCMonth(d.getUTCMonth() - 6);
+  return d.toISOString().slice(0, 10);
+}
+
 export default function () {
   const suiteId = uuidv4();
   // Test cases for different validation scenarios
@@ -22,7 +31,7 @@ export default function () {
         monthlyIncome: 50000,
         loanAmount: 240000,
         loanPurpose: 'education',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -37,7 +46,7 @@ export default function () {
         monthlyIncome: 50000,
         loanAmount: 240000,
         loanPurpose: 'education',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -53,7 +62,7 @@ export default function () {
         monthlyIncome: 4999, // Below minimum 5000
         loanAmount: 240000,
         loanPurpose: 'education',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -68,7 +77,7 @@ export default function () {
         monthlyIncome: 5000001, // Above maximum 5,000,000
         loanAmount: 240000,
         loanPurpose: 'education',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -84,7 +93,7 @@ export default function () {
         monthlyIncome: 50000,
         loanAmount: 999, // Below minimum 1000
         loanPurpose: 'education',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -99,7 +108,7 @@ export default function () {
         monthlyIncome: 50000,
         loanAmount: 5000001, // Above maximum 5,000,000
         loanPurpose: 'education',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -115,7 +124,7 @@ export default function () {
         monthlyIncome: 50000,
         loanAmount: 240000,
         loanPurpose: '', // Empty purpose
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -130,7 +139,7 @@ export default function () {
         monthlyIncome: 50000,
         loanAmount: 240000,
         loanPurpose: 'invalid_purpose', // Not in the list
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -139,35 +148,35 @@ export default function () {
         error: { "message": "Invalid request body", "reason": "Loan purpose must be one of: education, home, car, business, personal" }
       }
     },
-    // age validation
+    // dateOfBirth validation
     {
       payload: {
-        fullName: `${suiteId}-Zero Age User`,
+        fullName: `${suiteId}-Missing Date of Birth User`,
         monthlyIncome: 50000,
         loanAmount: 240000,
         loanPurpose: 'education',
-        // age missing
+        // dateOfBirth missing
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
       expected: {
         status: 400,
-        error: { "message": "Invalid request body", "reason": "missing required fields: age" }
+        error: { "message": "Invalid request body", "reason": "missing required fields: dateOfBirth" }
       }
     },
     {
       payload: {
-        fullName: `${suiteId}-Negative Age User`,
+        fullName: `${suiteId}-Future Date of Birth User`,
         monthlyIncome: 50000,
         loanAmount: 240000,
         loanPurpose: 'education',
-        age: -1, // Negative age
+        dateOfBirth: '2999-01-01', // Not a past date
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
       expected: {
         status: 400,
-        error: { "message": "Invalid request body", "reason": "Age must be a number more than 0" }
+        error: { "message": "Invalid request body", "reason": "Date of birth must be a past date in YYYY-MM-DD format" }
       }
     },
     // phoneNumber validation
@@ -177,7 +186,7 @@ export default function () {
         monthlyIncome: 50000,
         loanAmount: 240000,
         loanPurpose: 'education',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "123456789", // Doesn't start with 0
         email: "demo@example.com",
       },
@@ -192,7 +201,7 @@ export default function () {
         monthlyIncome: 50000,
         loanAmount: 240000,
         loanPurpose: 'education',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "08512A4567", // Contains non-numeric character
         email: "demo@example.com",
       },
@@ -208,7 +217,7 @@ export default function () {
         monthlyIncome: 50000,
         loanAmount: 240000,
         loanPurpose: 'education',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "not-an-email", // Invalid email format
       },
@@ -224,7 +233,7 @@ export default function () {
         monthlyIncome: 50000,
         // loanAmount missing
         loanPurpose: 'education',
-        age: 30,
+        dateOfBirth: dateOfBirth(30),
         phoneNumber: "0851234567",
         email: "demo@example.com",
       },
@@ -239,13 +248,13 @@ export default function () {
         // monthlyIncome missing
         // loanAmount missing
         // loanPurpose missing
-        // age missing
+        // dateOfBirth missing
         // phoneNumber missing
         // email missing
       },
       expected: {
         status: 400,
-        error: { "message": "Invalid request body", "reason": "missing required fields: fullName, monthlyIncome, loanAmount, loanPurpose, age, phoneNumber, email" }
+        error: { "message": "Invalid request body", "reason": "missing required fields: fullName, dateOfBirth, phoneNumber, email, monthlyIncome, loanAmount, loanPurpose" }
       }
     }
   ];
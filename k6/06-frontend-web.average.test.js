@@ -63,6 +63,15 @@ function generateRandomAge() {
   return Math.floor(Math.random() * 47) + 18;
 }
 
+// dateOfBirth is the date of birth of someone who turned age half a year ago,
+// so the applicant's age does not drift as the script gets older.
+function dateOfBirth(age) {
+  const d = new Date();
+  d.setUTCFullYear(d.getUTCFullYear() - age);
+  d.setUTCMonth(d.getUTCMonth() - 6);
+  return d.toISOString().slice(0, 10);
+}
+
 function generateRandomLoanPurpose() {
   const purposes = ['education', 'home', 'car', 'business', 'personal'];
   return purposes[Math.floor(Math.random() * purposes.length)];
@@ -109,7 +118,7 @@ export default function () {
     monthlyIncome: generateRandomIncome(),
     loanAmount: generateRandomLoanAmount(),
     loanPurpose: generateRandomLoanPurpose(),
-    age: generateRandomAge(),
+    dateOfBirth: dateOfBirth(generateRandomAge()),
     email: generateRandomEmail(fullName),
   };
 